@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/arzano/pgo/pkg/view"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var categoryCmd = &cobra.Command{
+	Use:   "category [name]",
+	Short: "List the packages of a category",
+	Long: `Lists the packages of the given category, i.e. dev-lang, with their newest
+version that is not a live version, whether it is stable or testing, and the
+newest stable version on the first configured arch. Without a category, all
+categories are listed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		if len(args) == 0 {
+			err = showCategories()
+		} else {
+			err = showCategory(args[0])
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func showCategories() error {
+	categories, err := newClient().GetCategories(context.Background())
+	if err != nil {
+		return err
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+
+	startPager()
+	defer stopPager()
+
+	maxLength := 0
+	for _, category := range categories {
+		maxLength = max(maxLength, len(category.Name))
+	}
+	for _, category := range categories {
+		fmt.Fprintln(stdout, au.Bold(category.Name).String()+strings.Repeat(" ", maxLength-len(category.Name))+"  "+category.Description)
+	}
+	return nil
+}
+
+func showCategory(name string) error {
+	category, err := newClient().GetCategory(context.Background(), name)
+	if err != nil {
+		return err
+	}
+	sort.Slice(category.Packages, func(i, j int) bool {
+		return category.Packages[i].Atom < category.Packages[j].Atom
+	})
+	arch := categoryArch()
+
+	startPager()
+	defer stopPager()
+
+	fmt.Fprintln(stdout)
+	printRule()
+	fmt.Fprintln(stdout)
+	for _, line := range centered(category.Name) {
+		fmt.Fprintln(stdout, au.Bold(line))
+	}
+	fmt.Fprintln(stdout)
+	for _, line := range centered(category.Description) {
+		fmt.Fprintln(stdout, line)
+	}
+	fmt.Fprintln(stdout)
+
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Packages"))))
+	if len(category.Packages) == 0 {
+		fmt.Fprintln(stdout, "  No packages")
+		return nil
+	}
+
+	var rows [][]string
+	for _, gpackage := range category.Packages {
+		newest, keyword, stable := "", "", ""
+		if version := models.NewestRelease(gpackage.Versions); version != nil {
+			newest = version.Version
+			keyword = archKeyword(version, arch)
+		}
+		if version := newestStable(gpackage.Versions, arch); version != nil {
+			stable = version.Version
+		}
+		rows = append(rows, []string{gpackage.Name, newest, keyword, stable, gpackage.Description()})
+	}
+
+	header := []string{"Package", "Newest", "Keyword", "Stable", "Description"}
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for idx, cell := range row {
+			widths[idx] = max(widths[idx], len(cell))
+		}
+	}
+	pad := func(cell string, idx int) string {
+		return cell + strings.Repeat(" ", widths[idx]-len(cell))
+	}
+
+	line := "  "
+	for idx, cell := range header[:len(header)-1] {
+		line += pad(cell, idx) + "  "
+	}
+	fmt.Fprintln(stdout, au.Bold(line+header[len(header)-1]))
+	for _, row := range rows {
+		fmt.Fprintln(stdout, "  "+au.Bold(pad(row[0], 0)).String()+"  "+pad(row[1], 1)+"  "+
+			keywordColor(row[2])+strings.Repeat(" ", widths[2]-len(row[2]))+"  "+pad(row[3], 3)+"  "+row[4])
+	}
+	return nil
+}
+
+// newestStable returns the newest version that is stable on the given arch
+func newestStable(versions []*models.Version, arch string) *models.Version {
+	var stable []*models.Version
+	for _, version := range versions {
+		if version.ParseKeywords().StableOn(arch) {
+			stable = append(stable, version)
+		}
+	}
+	return selectVersion(stable, "latest")
+}
+
+// categoryArch returns the first of the configured arches
+func categoryArch() string {
+	arches := archesFlag
+	if len(arches) == 0 {
+		arches = viper.GetStringSlice("packages.arches")
+	}
+	if len(arches) == 0 || arches[0] == "all" {
+		return view.DefaultArches[0]
+	}
+	return arches[0]
+}
+
+// archKeyword returns the keyword of the version on the given arch,
+// i.e. 'amd64' if it is stable or '~amd64' if it is testing
+func archKeyword(version *models.Version, arch string) string {
+	switch version.ParseKeywords().State(arch) {
+	case models.KeywordStable:
+		return arch
+	case models.KeywordTesting:
+		return "~" + arch
+	case models.KeywordDisabled:
+		return "-" + arch
+	}
+	return ""
+}
+
+// keywordColor colors stable keywords green and testing keywords yellow
+func keywordColor(keyword string) string {
+	switch {
+	case keyword == "":
+		return ""
+	case strings.HasPrefix(keyword, "~"):
+		return au.Yellow(keyword).String()
+	case strings.HasPrefix(keyword, "-"):
+		return au.Red(keyword).String()
+	}
+	return au.Green(keyword).String()
+}
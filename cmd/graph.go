@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/graph"
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/arzano/pgo/pkg/stringslice"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+var graphDirection string
+var graphVersion string
+
+var graphCmd = &cobra.Command{
+	Use:   "graph <atom>",
+	Short: "Print the dependency graph of a package",
+	Long: `Prints the dependencies of a version of the given package, i.e. dev-lang/go,
+and the packages depending on it, as graphviz dot or mermaid graph.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !stringslice.Contains(graph.Formats, graphFormat) {
+			return errors.New("unknown graph format '" + graphFormat + "', expected one of: " + strings.Join(graph.Formats, ", "))
+		}
+		if !stringslice.Contains([]string{"forward", "reverse", "both"}, graphDirection) {
+			return errors.New("unknown direction '" + graphDirection + "', expected one of: forward, reverse, both")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showGraph(args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func showGraph(atom string) error {
+	var sections client.Section
+	if graphDirection != "reverse" {
+		sections |= client.SectionForwardDependencies
+	}
+	if graphDirection != "forward" {
+		sections |= client.SectionDependencies
+	}
+
+	gpackage, err := newClient().GetPackage(context.Background(), atom, sections)
+	if err != nil {
+		return err
+	}
+
+	var version *models.Version
+	if graphDirection != "reverse" {
+		version = selectVersion(gpackage.Versions, graphVersion)
+		if version == nil {
+			return errors.New("version " + graphVersion + " of " + gpackage.Atom + " not found")
+		}
+	}
+	reverseDependencies := gpackage.ReverseDependencies
+	if graphDirection == "forward" {
+		reverseDependencies = nil
+	}
+	g := graph.Dependencies(gpackage.Atom, version, reverseDependencies)
+	return graph.Write(os.Stdout, graphFormat, g)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var maintainerCmd = &cobra.Command{
+	Use:   "maintainer <email|name>",
+	Short: "Show the packages of a maintainer",
+	Long: `Shows an overview of the given maintainer, i.e. go@gentoo.org, and lists
+the maintained packages. Instead of the email address, the name of the
+developer or project, i.e. "Go Project", can be given. A name that is not
+found is taken as nick, i.e. go for go@gentoo.org. The sections of the
+packages can be shown and filtered using the same flags as for packages,
+i.e. --bugs --bug-status CONFIRMED.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return parseFilters(cmd)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showMaintainer(args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func showMaintainer(maintainer string) error {
+	c := newClient()
+	email, err := maintainerEmail(c, maintainer)
+	if err != nil {
+		return err
+	}
+	gmaintainer, err := c.GetMaintainer(context.Background(), email)
+	if err != nil {
+		return err
+	}
+	packages, err := c.GetMaintainerPackages(context.Background(), email, activeSections())
+	if err != nil {
+		return err
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Atom < packages[j].Atom
+	})
+	useflags := describeUseflags(packages...)
+	for idx := range packages {
+		// packages without the version given by --for-version have no commits touching it
+		if err := applyFilters(&packages[idx]); errors.Is(err, errVersionNotFound) {
+			packages[idx].Commits = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	startPager()
+	defer stopPager()
+
+	fmt.Fprintln(stdout)
+	printRule()
+	fmt.Fprintln(stdout)
+	name := gmaintainer.Email
+	if gmaintainer.Name != "" {
+		name = gmaintainer.Name + " <" + gmaintainer.Email + ">"
+	}
+	for _, line := range centered(name) {
+		fmt.Fprintln(stdout, au.Bold(line))
+	}
+	if gmaintainer.Type != "" {
+		for _, line := range centered(gmaintainer.Type) {
+			fmt.Fprintln(stdout, line)
+		}
+	}
+	fmt.Fprintln(stdout)
+
+	printMaintainerOverview(gmaintainer.PackagesInformation)
+	printMaintainerPackages(packages)
+
+	if activeSections() != 0 {
+		for _, gpackage := range packages {
+			printPackage(gpackage, useflags)
+		}
+	}
+	return nil
+}
+
+// maintainerEmail returns the email address of the given maintainer, which
+// is either an email address or the name of a developer or project, i.e.
+// 'Go Project'. Names that are not found are taken as the nick of a Gentoo
+// developer or project, i.e. go for go@gentoo.org.
+func maintainerEmail(c *client.Client, maintainer string) (string, error) {
+	if strings.Contains(maintainer, "@") {
+		return maintainer, nil
+	}
+	maintainers, err := c.FindMaintainers(context.Background(), maintainer)
+	if err != nil {
+		return "", err
+	}
+	switch len(maintainers) {
+	case 0:
+		return maintainer + "@gentoo.org", nil
+	case 1:
+		return maintainers[0].Email, nil
+	}
+	var emails []string
+	for _, m := range maintainers {
+		emails = append(emails, m.Email)
+	}
+	return "", errors.New("'" + maintainer + "' refers to several maintainers, use one of: " + strings.Join(emails, ", "))
+}
+
+func printMaintainerOverview(info models.MaintainerPackagesInformation) {
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Overview"))))
+	counters := []struct {
+		name  string
+		count int
+	}{
+		{"Outdated", info.Outdated},
+		{"Pull Requests", info.PullRequests},
+		{"Bugs", info.Bugs},
+		{"Security Bugs", info.SecurityBugs},
+	}
+	for _, counter := range counters {
+		count := strconv.Itoa(counter.count)
+		if counter.count > 0 && (counter.name == "Security Bugs" || counter.name == "Outdated") {
+			fmt.Fprintln(stdout, "  "+counter.name+":"+strings.Repeat(" ", 16-len(counter.name))+au.Bold(au.Red(count)).String())
+		} else {
+			fmt.Fprintln(stdout, "  "+counter.name+":"+strings.Repeat(" ", 16-len(counter.name))+count)
+		}
+	}
+	fmt.Fprintln(stdout)
+}
+
+func printMaintainerPackages(packages []models.Package) {
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Packages"))))
+	if len(packages) == 0 {
+		fmt.Fprintln(stdout, "  No packages")
+		fmt.Fprintln(stdout)
+		return
+	}
+
+	maxLength := 0
+	for _, gpackage := range packages {
+		maxLength = max(maxLength, len(gpackage.Atom))
+	}
+	for _, gpackage := range packages {
+		latest := ""
+		if version := models.NewestRelease(gpackage.Versions); version != nil {
+			latest = version.Version
+		}
+		fmt.Fprintln(stdout, "  "+au.Bold(gpackage.Atom).String()+strings.Repeat(" ", maxLength-len(gpackage.Atom))+"  "+latest)
+	}
+	fmt.Fprintln(stdout)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var outdatedMaintainer string
+var outdatedCategory string
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List packages that are behind upstream",
+	Long: `Lists the packages whose newest version in Gentoo is behind the newest
+version released by upstream, starting with the packages that are furthest
+behind. The packages can be narrowed down using --maintainer and --category.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showOutdated(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// outdatedPackage is a package that is behind upstream
+type outdatedPackage struct {
+	models.OutdatedPackages
+	lag models.Lag
+}
+
+func showOutdated() error {
+	outdated, err := findOutdated()
+	if err != nil {
+		return err
+	}
+
+	var packages []outdatedPackage
+	for _, o := range outdated {
+		if outdatedCategory != "" && !strings.HasPrefix(o.Atom, outdatedCategory+"/") {
+			continue
+		}
+		if lag, behind := o.Lag(); behind {
+			packages = append(packages, outdatedPackage{o, lag})
+		}
+	}
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i].lag != packages[j].lag {
+			return packages[i].lag.GreaterThan(packages[j].lag)
+		}
+		return packages[i].Atom < packages[j].Atom
+	})
+
+	startPager()
+	defer stopPager()
+
+	if len(packages) == 0 {
+		fmt.Fprintln(stdout, "No outdated packages")
+		return nil
+	}
+
+	header := []string{"Package", "Gentoo", "Upstream", "Behind"}
+	rows := [][]string{header}
+	for _, p := range packages {
+		rows = append(rows, []string{p.Atom, p.GentooVersion, p.NewestVersion, p.lag.String()})
+	}
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for idx, cell := range row {
+			widths[idx] = max(widths[idx], len(cell))
+		}
+	}
+	for idx, row := range rows {
+		line := ""
+		for column, cell := range row[:len(row)-1] {
+			line += cell + strings.Repeat(" ", widths[column]-len(cell)) + "  "
+		}
+		line += row[len(row)-1]
+		if idx == 0 {
+			fmt.Fprintln(stdout, au.Bold(line))
+		} else {
+			fmt.Fprintln(stdout, line)
+		}
+	}
+	return nil
+}
+
+// findOutdated returns the outdated packages of the maintainer given
+// by --maintainer, or all outdated packages if none is given
+func findOutdated() ([]models.OutdatedPackages, error) {
+	c := newClient()
+	if outdatedMaintainer == "" {
+		return c.GetOutdatedPackages(context.Background())
+	}
+
+	email, err := maintainerEmail(c, outdatedMaintainer)
+	if err != nil {
+		return nil, err
+	}
+	packages, err := c.GetMaintainerPackages(context.Background(), email, client.SectionUpstream)
+	if err != nil {
+		return nil, err
+	}
+	var outdated []models.OutdatedPackages
+	for _, gpackage := range packages {
+		for _, o := range gpackage.Outdated {
+			o.Atom = gpackage.Atom
+			outdated = append(outdated, *o)
+		}
+	}
+	return outdated, nil
+}
@@ -5,10 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/filter"
 	"github.com/arzano/pgo/pkg/models"
-	. "github.com/logrusorgru/aurora"
-	"github.com/machinebox/graphql"
-	"log"
+	"github.com/arzano/pgo/pkg/stringslice"
+	"github.com/arzano/pgo/pkg/terminal"
+	"github.com/arzano/pgo/pkg/view"
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/viper"
 	"os"
 	"sort"
 	"strconv"
@@ -18,8 +22,13 @@ import (
 
 func showPackage(searchTerm string, first bool) {
 
+	if machineReadable() {
+		encodePackage(searchTerm, first)
+		return
+	}
+
 	fmt.Println()
-	fmt.Println("[ Results for search key : ", Bold(searchTerm), " ]")
+	fmt.Println("[ Results for search key : ", au.Bold(searchTerm), " ]")
 	fmt.Println("Searching...")
 	fmt.Println()
 
@@ -28,24 +37,55 @@ func showPackage(searchTerm string, first bool) {
 		fmt.Println()
 		fmt.Println(err)
 		fmt.Println()
+		os.Exit(1)
+	}
+
+	if err := applyFilters(&gpackage); err != nil {
+		fmt.Println()
+		fmt.Println(err)
+		fmt.Println()
+		os.Exit(1)
 	}
 
-	fmt.Println("")
-	fmt.Println("")
-	fmt.Println(Underline(Bold(strings.Repeat(" ", 50-len(gpackage.Atom)/2) + strings.Repeat(" ", len(gpackage.Atom)) + strings.Repeat(" ", 50-len(gpackage.Atom)/2))))
-	fmt.Println(strings.Repeat(" ", 50-len(gpackage.Atom)/2) + strings.Repeat(" ", len(gpackage.Atom)) + strings.Repeat(" ", 50-len(gpackage.Atom)/2))
-	fmt.Println(Bold(strings.Repeat(" ", 50-len(gpackage.Atom)/2) + gpackage.Atom + strings.Repeat(" ", 50-len(gpackage.Atom)/2)))
-	fmt.Println("")
-	fmt.Println(strings.Repeat(" ", 50-len(gpackage.Description())/2) + gpackage.Description())
-	fmt.Println(strings.Repeat(" ", 50-len(gpackage.Versions[0].Homepage[0])/2) + gpackage.Versions[0].Homepage[0])
-	fmt.Println("")
+	useflags := describeUseflags(gpackage)
+
+	startPager()
+	defer stopPager()
+
+	printPackage(gpackage, useflags)
+}
+
+// printPackage prints the header and the selected sections of the package,
+// describing its USE flags using the given USE flag records
+func printPackage(gpackage models.Package, useflags []models.Useflag) {
+	fmt.Fprintln(stdout, "")
+	fmt.Fprintln(stdout, "")
+	printRule()
+	fmt.Fprintln(stdout)
+	for _, line := range centered(gpackage.Atom) {
+		fmt.Fprintln(stdout, au.Bold(line))
+	}
+	fmt.Fprintln(stdout, "")
+	for _, line := range centered(gpackage.Description()) {
+		fmt.Fprintln(stdout, line)
+	}
+	if len(gpackage.Versions) > 0 && len(gpackage.Versions[0].Homepage) > 0 {
+		for _, line := range centered(gpackage.Versions[0].Homepage[0]) {
+			fmt.Fprintln(stdout, line)
+		}
+	}
+	fmt.Fprintln(stdout, "")
 
 	if showVersions {
 		printVersions(gpackage.Versions)
 	}
 
+	if showUpstream {
+		printUpstream(gpackage.Outdated)
+	}
+
 	if showMetadata {
-		printMetadata(gpackage)
+		printMetadata(gpackage, useflags)
 	}
 
 	if showBugs {
@@ -60,6 +100,10 @@ func showPackage(searchTerm string, first bool) {
 		printQAReports(gpackage)
 	}
 
+	if depsVersion != "" {
+		printForwardDependencies(gpackage)
+	}
+
 	if showDependencies {
 		printDependencies(gpackage)
 	}
@@ -68,13 +112,42 @@ func showPackage(searchTerm string, first bool) {
 		printChangelog(gpackage.Commits)
 	}
 
-	fmt.Println()
-	fmt.Println(Underline(Bold(strings.Repeat(" ", 50-len(gpackage.Atom)/2) + strings.Repeat(" ", len(gpackage.Atom)) + strings.Repeat(" ", 50-len(gpackage.Atom)/2))))
-	fmt.Println()
+	fmt.Fprintln(stdout)
+	printRule()
+	fmt.Fprintln(stdout)
+}
+
+// encodePackage writes the requested sections of the package in a
+// machine-readable format or using the user-defined template to stdout
+func encodePackage(searchTerm string, first bool) {
+	gpackage, err := findPackage(searchTerm, first)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := applyFilters(&gpackage); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if packageTemplate != nil {
+		err = packageTemplate.Execute(os.Stdout, gpackage)
+		fmt.Println()
+	} else {
+		var data interface{} = view.NewPackage(gpackage, activeSections(), describeUseflags(gpackage))
+		if outputFormat == "markdown" || outputFormat == "html" {
+			data = view.Report{Package: data.(view.Package), Arches: selectedArches(gpackage.Versions)}
+		}
+		err = view.Encode(os.Stdout, outputFormat, data)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
 func printVersions(versions []*models.Version) {
-	fmt.Println(Underline(Bold(Green("Available Versions"))))
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Available Versions"))))
 	sort.Slice(versions, func(i, j int) bool {
 		return versions[i].GreaterThan(*versions[j])
 	})
@@ -86,100 +159,325 @@ func printVersions(versions []*models.Version) {
 		}
 	}
 
-	arches := []string{"amd64", "x86", "alpha", "arm", "arm64", "hppa", "ia64", "ppc", "ppc64", "sparc"}
+	arches := selectedArches(versions)
 
-	fmt.Print(strings.Repeat(" ", maxLength + 4))
+	// the widths of the additional columns in --long mode
+	columnWidths := make([]int, len(longColumnNames))
+	for idx, name := range longColumnNames {
+		columnWidths[idx] = len(name)
+	}
+	for _, version := range versions {
+		for idx, column := range longColumns(version) {
+			columnWidths[idx] = max(columnWidths[idx], len(column))
+		}
+	}
+
+	fmt.Fprint(stdout, strings.Repeat(" ", maxLength+5))
 	for _, arch := range arches {
-		fmt.Print(" " +  arch + " ")
+		fmt.Fprint(stdout, " "+arch+" ")
+	}
+	if longVersions {
+		for idx, name := range longColumnNames {
+			fmt.Fprintf(stdout, "  %-*s", columnWidths[idx], name)
+		}
+	}
+	fmt.Fprintln(stdout)
+
+	// reference the masks of the versions in the legend
+	masks := view.CollectMasks(versions)
+	maskRefs := map[string]string{}
+	for idx, mask := range masks {
+		for _, version := range mask.Covers {
+			maskRefs[version] += "[" + strconv.Itoa(idx+1) + "]"
+		}
 	}
-	fmt.Println()
 
-	for _, version := range versions {
-		fmt.Print(Bold("  "+version.Version+":  " + strings.Repeat(" ", maxLength - len(version.Version))))
-
-		for _, arch := range arches {
-			if len(version.Masks) != 0 {
-				fmt.Print(Red("  x   "))
-			} else if strings.Contains(" " + version.Keywords + " ", " " + arch + " ") {
-				fmt.Print(Green("  +   "))
-			} else if strings.Contains(" " + version.Keywords + " ", " ~" + arch + " ") {
-				fmt.Print(Yellow("  ~   "))
-			} else {
-				fmt.Print("      ")
+	if !longVersions {
+		for _, version := range versions {
+			printVersion(version, arches, maxLength, nil, maskRefs[version.Version])
+		}
+	} else {
+		for _, slot := range groupBySlot(versions) {
+			fmt.Fprintln(stdout, au.Bold("  Slot "+slot[0].Slot))
+			for _, version := range slot {
+				printVersion(version, arches, maxLength, columnWidths, maskRefs[version.Version])
 			}
 		}
-		fmt.Println()
 	}
-	fmt.Println("")
+	fmt.Fprintln(stdout, "")
+
+	printMasks(masks)
 }
 
-func printMetadata(gpackage models.Package) {
-	fmt.Println(Underline(Bold(Green("Package Metadata"))))
-	if gpackage.Longdescription != "" {
-		fmt.Println(Bold("  Full description: "), gpackage.Longdescription)
-	}
-	var useflags []string
-	var useExpands []string
-	if gpackage.Versions[0].Version != "9999" {
-		for _, useflag := range gpackage.Versions[0].Useflags {
-			if !strings.HasPrefix(useflag, gpackage.Name+"_") {
-				useflags = append(useflags, useflag)
-			} else {
-				useExpands = append(useExpands, useflag)
+// printMasks prints the legend of the masks referenced in the versions table
+func printMasks(masks []view.Mask) {
+	if len(masks) > 0 {
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Masks"))))
+		for idx, mask := range masks {
+			fmt.Fprintln(stdout, au.Red("  ["+strconv.Itoa(idx+1)+"]"), au.Bold(mask.Versions), "(covers "+strings.Join(mask.Covers, ", ")+")")
+			fmt.Fprintln(stdout, "      Masked by "+mask.Author+" <"+mask.AuthorEmail+"> on "+mask.Date.Format("2006-01-02"))
+			for _, line := range strings.Split(strings.TrimSpace(mask.Reason), "\n") {
+				fmt.Fprintln(stdout, "      "+line)
 			}
 		}
-	} else if len(gpackage.Versions) > 1 {
-		for _, useflag := range gpackage.Versions[0].Useflags {
-			if !strings.HasPrefix(useflag, gpackage.Name+"_") {
-				useflags = append(useflags, useflag)
-			} else {
-				useExpands = append(useExpands, useflag)
-			}
+		fmt.Fprintln(stdout)
+	}
+}
+
+// longColumnNames are the names of the additional columns in --long mode
+var longColumnNames = []string{"EAPI", "Subslot", "Restricts", "Properties"}
+
+// longColumns returns the additional columns of the version in --long mode
+func longColumns(version *models.Version) []string {
+	return []string{
+		version.EAPI,
+		version.Subslot,
+		strings.Join(version.Restricts, ","),
+		strings.Join(version.Properties, ","),
+	}
+}
+
+// printVersion prints a row of the versions table. The additional columns
+// are only printed if their widths are given. The row ends with the
+// references to the masks of the version.
+func printVersion(version *models.Version, arches []string, maxLength int, columnWidths []int, maskRefs string) {
+	fmt.Fprint(stdout, au.Bold("  "+version.Version+":  "+strings.Repeat(" ", maxLength-len(version.Version))))
+
+	keywords := version.ParseKeywords()
+	for _, arch := range arches {
+		// center the symbol below the arch
+		cell := func(symbol string) string {
+			return terminal.Center(symbol, len(arch)+2)
 		}
+		if len(version.Masks) != 0 {
+			fmt.Fprint(stdout, au.Red(cell("x")))
+			continue
+		}
+		switch keywords.State(arch) {
+		case models.KeywordStable:
+			fmt.Fprint(stdout, au.Green(cell("+")))
+		case models.KeywordTesting:
+			fmt.Fprint(stdout, au.Yellow(cell("~")))
+		case models.KeywordDisabled:
+			fmt.Fprint(stdout, au.Red(cell("-")))
+		default:
+			fmt.Fprint(stdout, cell(""))
+		}
+	}
+
+	if columnWidths != nil {
+		for idx, column := range longColumns(version) {
+			fmt.Fprintf(stdout, "  %-*s", columnWidths[idx], column)
+		}
+	}
+	if maskRefs != "" {
+		fmt.Fprint(stdout, au.Red(" "+maskRefs))
 	}
-	fmt.Println(Bold("  Useflags: "), strings.Join(useflags, ", "))
-	fmt.Println(Bold("  Use Expands: "), strings.Join(useExpands, ", "))
-	fmt.Println(Bold("  License: "), gpackage.Versions[0].License)
-	fmt.Print(Bold("  Maintainers: "))
+	fmt.Fprintln(stdout)
+}
+
+// groupBySlot groups the sorted versions by their slot. The
+// slots are ordered by their newest version.
+func groupBySlot(versions []*models.Version) [][]*models.Version {
+	var slots [][]*models.Version
+	slotIdx := map[string]int{}
+	for _, version := range versions {
+		idx, ok := slotIdx[version.Slot]
+		if !ok {
+			idx = len(slots)
+			slotIdx[version.Slot] = idx
+			slots = append(slots, nil)
+		}
+		slots[idx] = append(slots[idx], version)
+	}
+	return slots
+}
+
+func printUpstream(outdated []*models.OutdatedPackages) {
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Upstream"))))
+	if len(outdated) == 0 {
+		fmt.Fprintln(stdout, "  Up to date")
+		fmt.Fprintln(stdout)
+		return
+	}
+	for _, o := range outdated {
+		lag, behind := o.Lag()
+		if !behind {
+			continue
+		}
+		fmt.Fprintln(stdout, "  "+o.GentooVersion+" in Gentoo, "+au.Bold(o.NewestVersion).String()+" released upstream ("+lag.String()+" behind)")
+	}
+	fmt.Fprintln(stdout)
+}
+
+func printMetadata(gpackage models.Package, useflags []models.Useflag) {
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Package Metadata"))))
+	if gpackage.Longdescription != "" {
+		fmt.Fprintln(stdout, au.Bold("  Full description: "), gpackage.Longdescription)
+	}
+	printUseflags(gpackage, useflags)
+	fmt.Fprintln(stdout, au.Bold("  License: "), gpackage.Versions[0].License)
+	fmt.Fprint(stdout, au.Bold("  Maintainers: "))
 	for idx, maintainer := range gpackage.Maintainers {
 		if idx < len(gpackage.Maintainers)-1 {
 			if maintainer.Name != "" {
-				fmt.Print(maintainer.Name + ", ")
+				fmt.Fprint(stdout, maintainer.Name+", ")
 			} else {
-				fmt.Print(maintainer.Email + ", ")
+				fmt.Fprint(stdout, maintainer.Email+", ")
 			}
 		} else {
 			if maintainer.Name != "" {
-				fmt.Print(maintainer.Name)
+				fmt.Fprint(stdout, maintainer.Name)
 			} else {
-				fmt.Print(maintainer.Email)
+				fmt.Fprint(stdout, maintainer.Email)
 			}
 		}
 	}
-	fmt.Println()
-	fmt.Println()
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout)
+}
+
+// printUseflags prints the USE flags of the newest version that is not a
+// live version, together with their descriptions. USE_EXPAND flags are
+// grouped by their USE_EXPAND name.
+func printUseflags(gpackage models.Package, records []models.Useflag) {
+	useflags := view.PackageUseflags(gpackage, records)
+
+	// pad the flags to the longest flag of their USE_EXPAND group
+	maxLength := map[string]int{}
+	for _, useflag := range useflags {
+		maxLength[useflag.UseExpand] = max(maxLength[useflag.UseExpand], len(useflagName(useflag)))
+	}
+
+	fmt.Fprintln(stdout, au.Bold("  Useflags: "))
+	for idx, useflag := range useflags {
+		if useflag.UseExpand != "" && (idx == 0 || useflags[idx-1].UseExpand != useflag.UseExpand) {
+			fmt.Fprintln(stdout, au.Bold("  "+strings.ToUpper(useflag.UseExpand)+": "))
+		}
+		name := useflagName(useflag)
+		if useflag.Description == "" {
+			fmt.Fprintln(stdout, "    "+name)
+		} else {
+			fmt.Fprintln(stdout, "    "+name+strings.Repeat(" ", maxLength[useflag.UseExpand]-len(name))+"  "+useflag.Description)
+		}
+	}
+}
+
+// useflagName returns the name of the flag without its USE_EXPAND prefix
+func useflagName(useflag view.Useflag) string {
+	return strings.TrimPrefix(useflag.Name, useflag.UseExpand+"_")
 }
 
 func printBugs(bugs []*models.Bug) {
 	if len(bugs) > 0 {
-		fmt.Println(Underline(Bold(Green("Bugs"))))
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Bugs"))))
+
+		var securityBugs, otherBugs []*models.Bug
+		for _, bug := range bugs {
+			if bug.IsSecurity() {
+				securityBugs = append(securityBugs, bug)
+			} else {
+				otherBugs = append(otherBugs, bug)
+			}
+		}
+
+		// align the columns of all bugs
+		widths := make([]int, 4)
 		for _, bug := range bugs {
-			fmt.Println("  " + bug.Id + ": " + bug.Summary)
+			for idx, column := range bugColumns(bug) {
+				widths[idx] = max(widths[idx], len(column))
+			}
 		}
-		fmt.Println()
+
+		if len(securityBugs) > 0 {
+			fmt.Fprintln(stdout, au.Bold(au.Red("  Security")))
+			for _, bug := range securityBugs {
+				fmt.Fprintln(stdout, au.Red(formatBug(bug, widths, "    ")))
+			}
+		}
+		if len(otherBugs) > 0 {
+			indent := "  "
+			if len(securityBugs) > 0 {
+				fmt.Fprintln(stdout, au.Bold("  Other"))
+				indent = "    "
+			}
+			for _, bug := range otherBugs {
+				fmt.Fprintln(stdout, formatBug(bug, widths, indent))
+			}
+		}
+		fmt.Fprintln(stdout)
 	}
 }
 
+// bugColumns returns the aligned columns of a bug
+func bugColumns(bug *models.Bug) []string {
+	return []string{bug.Id, bug.Status, bug.Component, bug.Assignee}
+}
+
+// formatBug returns a row of the bugs table
+func formatBug(bug *models.Bug, widths []int, indent string) string {
+	var columns []string
+	for idx, column := range bugColumns(bug) {
+		columns = append(columns, fmt.Sprintf("%-*s", widths[idx], column))
+	}
+	return indent + strings.Join(columns, " ") + "  " + bug.Summary
+}
+
 func printPullRequests(pullRequests []*models.GithubPullRequest) {
 	if len(pullRequests) > 0 {
-		fmt.Println(Underline(Bold(Green("Pull Requests"))))
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Pull Requests"))))
 		for _, pr := range pullRequests {
-			fmt.Println("  " + pr.Id + ": " + pr.Title + " (" + pr.Author + ")")
+			fmt.Fprintln(stdout, "  "+pr.Id+": "+pr.Title+" ("+pr.Author+")")
+
+			fmt.Fprint(stdout, "      CI: ", ciState(pr.CiState))
+			if pr.CiStateLink != "" {
+				fmt.Fprint(stdout, " ("+pr.CiStateLink+")")
+			}
+			fmt.Fprint(stdout, ", "+strconv.Itoa(pr.Comments)+" comments")
+			if createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt); err == nil {
+				fmt.Fprint(stdout, ", opened "+createdAt.Format("2006-01-02"))
+			}
+			if updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt); err == nil {
+				fmt.Fprint(stdout, ", updated "+updatedAt.Format("2006-01-02")+" ("+strconv.Itoa(int(time.Since(updatedAt).Hours()/24))+" days ago)")
+			}
+			fmt.Fprintln(stdout)
+
+			if len(pr.Labels) > 0 {
+				var labels []string
+				for _, label := range pr.Labels {
+					labels = append(labels, label.Name)
+				}
+				fmt.Fprintln(stdout, "      Labels: "+strings.Join(labels, ", "))
+			}
+
+			for idx, file := range pr.Files {
+				if idx == maxPullRequestFiles {
+					fmt.Fprintln(stdout, "      ... and "+strconv.Itoa(len(pr.Files)-maxPullRequestFiles)+" more files")
+					break
+				}
+				fmt.Fprintln(stdout, "     ", au.Green("+"+strconv.Itoa(file.Additions)), au.Red("-"+strconv.Itoa(file.Deletions)), file.Path)
+			}
 		}
-		fmt.Println()
+		fmt.Fprintln(stdout)
 	}
 }
 
+// maxPullRequestFiles is the number of changed files shown per pull request
+const maxPullRequestFiles = 5
+
+// ciState returns the colored state of the CI
+func ciState(state string) aurora.Value {
+	switch strings.ToLower(state) {
+	case "success":
+		return au.Green(strings.ToLower(state))
+	case "failure", "error":
+		return au.Red(strings.ToLower(state))
+	case "":
+		return au.Reset("unknown")
+	}
+	return au.Yellow(strings.ToLower(state))
+}
+
 func printQAReports(gpackage models.Package) {
 	qareportsfound := false
 	for _, version := range gpackage.Versions {
@@ -188,169 +486,264 @@ func printQAReports(gpackage models.Package) {
 		}
 	}
 	if len(gpackage.PkgCheckResults) > 0 || qareportsfound {
-		fmt.Println(Underline(Bold(Green("QA Report"))))
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("QA Report"))))
 		if len(gpackage.PkgCheckResults) > 0 {
-			fmt.Println(Bold("  All Versions: "))
+			fmt.Fprintln(stdout, au.Bold("  All Versions: "))
 		}
 		for _, qareport := range gpackage.PkgCheckResults {
-			fmt.Println("    - " + qareport.Class + ": " + qareport.Message)
+			fmt.Fprintln(stdout, "    - "+qareport.Class+": "+qareport.Message)
 		}
 		for _, version := range gpackage.Versions {
 			if len(version.PkgCheckResults) > 0 {
-				fmt.Println(Bold("  " + version.Version + ": "))
+				fmt.Fprintln(stdout, au.Bold("  "+version.Version+": "))
 				for _, qareport := range version.PkgCheckResults {
-					fmt.Println("    - " + qareport.Class + ": " + qareport.Message)
+					fmt.Fprintln(stdout, "    - "+qareport.Class+": "+qareport.Message)
 				}
 			}
 		}
-		fmt.Println("")
+		fmt.Fprintln(stdout, "")
 	}
 }
 
-func printDependencies(gpackage models.Package) {
-	if len(gpackage.ReverseDependencies) > 0 {
-		fmt.Println(Underline(Bold(Green("Reverse Dependencies"))))
-		var revDeps []string
-		for _, revDep := range gpackage.ReverseDependencies {
-			revDeps = append(revDeps, revDep.ReverseDependencyAtom)
-		}
-		revDeps = Deduplicate(revDeps)
-		for _, revDep := range revDeps {
-			fmt.Println("  - " + revDep)
-		}
+func printForwardDependencies(gpackage models.Package) {
+	version := selectVersion(gpackage.Versions, depsVersion)
+	if version == nil {
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Dependencies"))))
+		fmt.Fprintln(stdout, "  Version "+depsVersion+" not found")
+		fmt.Fprintln(stdout)
+		return
 	}
-	fmt.Println("")
-}
 
-func printChangelog(commits []*models.Commit) {
-	fmt.Println(Underline(Bold(Green("Changelog"))))
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Dependencies of "+gpackage.Atom+"-"+version.Version))))
+	dependencies := view.Dependencies(version.Dependencies)
+	if len(dependencies) == 0 {
+		fmt.Fprintln(stdout, "  No dependencies")
+		fmt.Fprintln(stdout)
+		return
+	}
 
-	sort.Slice(commits, func(i, j int) bool {
-		return commits[i].PrecedingCommits > commits[j].PrecedingCommits
-	})
+	printDependencyTable("Atom", dependencies)
+}
 
-	for idx, commit := range commits {
-		fmt.Println("  " + commit.CommitterDate.Format(time.RFC822) + ", " + commit.Id[:7] + ": " + commit.Message + " (" + commit.CommitterName + ")")
-		if idx == 15-1 {
-			break
+// printDependencyTable prints the given dependencies as a table with
+// one column per dependency type
+func printDependencyTable(header string, dependencies []view.Dependency) {
+	maxLength := len(header)
+	for _, dependency := range dependencies {
+		maxLength = max(maxLength, len(dependency.Atom))
+	}
+	columns := []string{"DEPEND", "RDEPEND", "BDEPEND", "PDEPEND"}
+
+	fmt.Fprint(stdout, au.Bold("  "+header+strings.Repeat(" ", maxLength-len(header))))
+	for _, column := range columns {
+		fmt.Fprint(stdout, au.Bold("  "+column))
+	}
+	fmt.Fprintln(stdout, au.Bold("  Condition"))
+
+	for _, dependency := range dependencies {
+		fmt.Fprint(stdout, "  "+dependency.Atom+strings.Repeat(" ", maxLength-len(dependency.Atom)))
+		for _, column := range columns {
+			cell := ""
+			for _, depType := range dependency.Types {
+				if strings.EqualFold(depType, column) {
+					cell = "x"
+				}
+			}
+			fmt.Fprint(stdout, "  "+terminal.Center(cell, len(column)))
 		}
+		fmt.Fprintln(stdout, "  "+dependency.Condition)
 	}
+	fmt.Fprintln(stdout)
 }
 
-func findPackage(searchTerm string, first bool) (models.Package, error) {
-	// create a client (safe to share across requests)
-	client := graphql.NewClient("https://packages.gentoo.org/api/graphql/")
+// selectVersion returns the version with the given version string,
+// or the newest version for 'latest'. Nil is returned if none exists.
+func selectVersion(versions []*models.Version, selected string) *models.Version {
+	var found *models.Version
+	for _, version := range versions {
+		if selected == "latest" {
+			if found == nil || version.GreaterThan(*found) {
+				found = version
+			}
+		} else if version.Version == selected {
+			found = version
+		}
+	}
+	return found
+}
 
-	// make a request
-	resultSize := "10"
-	if first {
-		resultSize = "1"
+func printDependencies(gpackage models.Package) {
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Reverse Dependencies"))))
+	dependencies := view.ReverseDependencies(gpackage.ReverseDependencies)
+	if len(dependencies) == 0 {
+		fmt.Fprintln(stdout, "  No reverse dependencies")
+		fmt.Fprintln(stdout)
+		return
 	}
+	printDependencyTable("Version", dependencies)
+}
 
-	req := graphql.NewRequest(buildSearchQuery(searchTerm, resultSize))
+func printChangelog(commits []*models.Commit) {
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Changelog"))))
+	if len(commits) == 0 {
+		fmt.Fprintln(stdout, "  No commits")
+		return
+	}
 
-	// set header fields
-	req.Header.Set("Cache-Control", "no-cache")
+	for _, commit := range commits {
+		fmt.Fprintln(stdout, "  "+commit.CommitterDate.Format(time.RFC822)+", "+commit.Id[:7]+": "+commit.Message+" ("+commit.CommitterName+")")
+	}
+}
 
-	// define a Context for the request
-	ctx := context.Background()
+func findPackage(searchTerm string, first bool) (models.Package, error) {
+	resultSize := 10
+	if first {
+		resultSize = 1
+	}
 
-	// run it and capture the response
-	var respData struct {
-		PackageSearch []models.Package
+	packages, err := newClient().SearchPackages(context.Background(), searchTerm, resultSize, activeSections())
+	if err != nil {
+		return models.Package{}, err
 	}
-	if err := client.Run(ctx, req, &respData); err != nil {
-		log.Fatal(err)
+	if len(packages) == 0 {
+		return models.Package{}, errors.New("No packages found for '" + searchTerm + "'")
 	}
 
 	var gpackage models.Package
 
-	if len(respData.PackageSearch) == 1 {
-		gpackage = respData.PackageSearch[0]
+	// keep stdout clean for machine-readable output
+	out := os.Stdout
+	if machineReadable() {
+		out = os.Stderr
+	}
+
+	if len(packages) == 1 {
+		gpackage = packages[0]
 	} else {
-		for idx, gpackage := range respData.PackageSearch {
-			fmt.Println(Bold(Green("["+strconv.Itoa(idx)+"] ")), Bold(gpackage.Atom))
-			fmt.Println("      ", Green("Homepage:      "), strings.Join(gpackage.Versions[0].Homepage, ", "))
-			fmt.Println("      ", Green("Description:   "), gpackage.Versions[0].Description)
-			fmt.Println("      ", Green("License:       "), gpackage.Versions[0].License)
-			fmt.Println()
+		for idx, gpackage := range packages {
+			fmt.Fprintln(out, au.Bold(au.Green("["+strconv.Itoa(idx)+"] ")), au.Bold(gpackage.Atom))
+			fmt.Fprintln(out, "      ", au.Green("Homepage:      "), strings.Join(gpackage.Versions[0].Homepage, ", "))
+			fmt.Fprintln(out, "      ", au.Green("Description:   "), gpackage.Versions[0].Description)
+			fmt.Fprintln(out, "      ", au.Green("License:       "), gpackage.Versions[0].License)
+			fmt.Fprintln(out)
 
 			if idx >= 10 {
 				break
 			}
 		}
 
-		fmt.Println("[ Applications found : ", Bold(strconv.Itoa(len(respData.PackageSearch))), " ]")
-		fmt.Println()
+		fmt.Fprintln(out, "[ Applications found : ", au.Bold(strconv.Itoa(len(packages))), " ]")
+		fmt.Fprintln(out)
 
 		reader := bufio.NewReader(os.Stdin)
-		fmt.Print(Bold("Which package have you been looking for? "), "[", Bold(Green("0-"+strconv.Itoa(min(10-1, len(respData.PackageSearch)-1)))), "] ")
+		fmt.Fprint(out, au.Bold("Which package have you been looking for? "), "[", au.Bold(au.Green("0-"+strconv.Itoa(min(10-1, len(packages)-1)))), "] ")
 		text, _ := reader.ReadString('\n')
 
 		selectedIdx, err := strconv.Atoi(strings.ReplaceAll(text, "\n", ""))
 
-		if err != nil || selectedIdx < 0 || selectedIdx > min(10, len(respData.PackageSearch)-1) {
+		if err != nil || selectedIdx < 0 || selectedIdx > min(10, len(packages)-1) {
 			return models.Package{}, errors.New("Invalid selection. Aborting...")
 		}
 
-		gpackage = respData.PackageSearch[selectedIdx]
+		gpackage = packages[selectedIdx]
 	}
 
 	return gpackage, nil
 }
 
-func buildSearchQuery(searchTerm, resultSize string) string {
-	return `
-    {
-	  packageSearch(searchTerm: "` + searchTerm + `", resultSize: ` + resultSize + `){
-		Name,
-        Atom,
-		Versions {
-		  Description,
-          Homepage,
-          Version,
-          License,
-          Keywords,
-          Useflags,
-          PkgCheckResults {
-            Class,
-            Message
-          },
-          Masks {
-            Versions,
-          }
-		},
-        Longdescription,        
-        Maintainers {
-          Name,
-          Email
-        },
-        Bugs {
-          Id,
-          Summary,
-        },
-        PullRequests {
-          Id,
-          Title,
-          Author,
-        },
-        PkgCheckResults {
-          Class,
-          Message
-        }
-        Commits {
-          Id,
-          CommitterName,
-          Message,
-          PrecedingCommits,
-          CommitterDate,
-        },
-        ReverseDependencies {
-          ReverseDependencyAtom,
-        }
-	  }
-	}
-	`
+// describeUseflags looks up the descriptions of the USE flags of the given
+// packages using a single request, if the metadata is shown. The
+// descriptions are optional, so in case the lookup fails a warning is
+// printed and the flags are shown without them.
+func describeUseflags(packages ...models.Package) []models.Useflag {
+	if activeSections()&client.SectionMetadata == 0 {
+		return nil
+	}
+	var names []string
+	for _, gpackage := range packages {
+		names = append(names, view.UseflagNames(gpackage)...)
+	}
+	records, err := newClient().LookupUseflags(context.Background(), stringslice.Unique(names))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "warning: USE flag descriptions are not available:", err)
+		return nil
+	}
+	return records
+}
+
+// errVersionNotFound is returned if the version given by --for-version doesn't exist
+var errVersionNotFound = errors.New("not found")
+
+// applyFilters narrows down the sections of the package using the filter
+// flags. An error is returned if the version given by --for-version
+// doesn't exist.
+func applyFilters(gpackage *models.Package) error {
+	gpackage.Bugs = filter.Bugs(gpackage.Bugs, bugFilter)
+	gpackage.PullRequests = filter.PullRequests(gpackage.PullRequests, pullRequestFilter, time.Now())
+	gpackage.ReverseDependencies = filter.ReverseDependencies(gpackage.ReverseDependencies, revDepTypes)
+	gpackage.Outdated = filter.Outdated(gpackage.Outdated)
+	if changelogVersion != "" {
+		version := selectVersion(gpackage.Versions, changelogVersion)
+		if version == nil {
+			return fmt.Errorf("version %s of %s %w", changelogVersion, gpackage.Atom, errVersionNotFound)
+		}
+		gpackage.Commits = filter.VersionCommits(gpackage.Commits, version)
+	}
+	gpackage.Commits = filter.Commits(gpackage.Commits, commitFilter)
+	return nil
+}
+
+// activeSections returns the sections of a package that are going to be displayed
+func activeSections() client.Section {
+	var sections client.Section
+	if showVersions {
+		sections |= client.SectionVersions
+	}
+	if showMetadata {
+		sections |= client.SectionMetadata
+	}
+	if showBugs {
+		sections |= client.SectionBugs
+	}
+	if showPullRequests {
+		sections |= client.SectionPullRequests
+	}
+	if showQAreports {
+		sections |= client.SectionQAReports
+	}
+	if showDependencies {
+		sections |= client.SectionDependencies
+	}
+	if showChangelog {
+		sections |= client.SectionChangelog
+	}
+	if depsVersion != "" {
+		sections |= client.SectionForwardDependencies
+	}
+	if showUpstream {
+		sections |= client.SectionUpstream
+	}
+	if showChangelog && changelogVersion != "" {
+		sections |= client.SectionVersionCommits
+	}
+	return sections
+}
+
+// selectedArches returns the arches given by --arches or the packages.arches
+// config key. In case they are 'all', the arches of the keywords are returned.
+func selectedArches(versions []*models.Version) []string {
+	arches := archesFlag
+	if len(arches) == 0 {
+		arches = viper.GetStringSlice("packages.arches")
+	}
+	if len(arches) == 1 && arches[0] == "all" {
+		var keywords []string
+		for _, version := range versions {
+			keywords = append(keywords, version.Keywords)
+		}
+		return view.AllArches(keywords)
+	}
+	return arches
 }
 
 func min(a, b int) int {
@@ -360,23 +753,9 @@ func min(a, b int) int {
 	return b
 }
 
-func Deduplicate(items []string) []string {
-	if items != nil && len(items) > 1 {
-		sort.Strings(items)
-		j := 0
-		for i := 1; i < len(items); i++ {
-			if items[j] == items[i] {
-				continue
-			}
-			j++
-			// preserve the original data
-			// in[i], in[j] = in[j], in[i]
-			// only set what is required
-			items[j] = items[i]
-		}
-		result := items[:j+1]
-		return result
-	} else {
-		return items
+func max(a, b int) int {
+	if a > b {
+		return a
 	}
+	return b
 }
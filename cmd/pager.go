@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/viper"
+)
+
+// stdout is where the output is written to. It
+// is buffered while the pager has been started.
+var stdout io.Writer = os.Stdout
+
+var noPager bool
+
+// pagerBuffer contains the output while the pager has been started
+var pagerBuffer *bytes.Buffer
+
+// startPager buffers the output, if it is going to a terminal and the pager
+// has not been disabled, to decide whether a pager is needed in stopPager
+func startPager() {
+	if !isTerminal || noPager {
+		return
+	}
+	pagerBuffer = &bytes.Buffer{}
+	stdout = pagerBuffer
+}
+
+// stopPager writes the buffered output through the pager if it is taller
+// than the terminal, and directly to stdout otherwise
+func stopPager() {
+	if pagerBuffer == nil {
+		return
+	}
+	output := pagerBuffer
+	pagerBuffer = nil
+	stdout = os.Stdout
+
+	if bytes.Count(output.Bytes(), []byte("\n")) < height {
+		output.WriteTo(os.Stdout)
+		return
+	}
+
+	// the 'pager' config key is overridden by $PAGER through the automatic environment
+	pager := exec.Command("sh", "-c", viper.GetString("pager"))
+	pager.Stdin = bytes.NewReader(output.Bytes())
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if err := pager.Start(); err != nil {
+		// fall back to printing the output directly
+		output.WriteTo(os.Stdout)
+		return
+	}
+	pager.Wait()
+}
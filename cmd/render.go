@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/terminal"
+	"github.com/logrusorgru/aurora"
+)
+
+// maxWidth is the maximum width of the rendered output
+const maxWidth = 100
+
+var colorMode string
+
+// au colorizes the output, if colors are enabled
+var au = aurora.NewAurora(true)
+
+// isTerminal is true if stdout is a terminal
+var isTerminal bool
+
+// width is the width the output is rendered in
+var width = maxWidth
+
+// height is the height of the terminal
+var height int
+
+// setupRendering detects whether stdout is a terminal and its
+// width, and enables colors based on --color and NO_COLOR
+func setupRendering() error {
+	var terminalWidth int
+	terminalWidth, height, isTerminal = terminal.Size(os.Stdout.Fd())
+	if isTerminal && terminalWidth > 0 {
+		width = min(terminalWidth, maxWidth)
+	}
+
+	switch colorMode {
+	case "auto":
+		_, noColor := os.LookupEnv("NO_COLOR")
+		au = aurora.NewAurora(isTerminal && !noColor)
+	case "always":
+		au = aurora.NewAurora(true)
+	case "never":
+		au = aurora.NewAurora(false)
+	default:
+		return errors.New("invalid color mode '" + colorMode + "', expected one of: auto, always, never")
+	}
+	return nil
+}
+
+// centered returns the lines of the given text centered, wrapping it if it is
+// too long. In case stdout is not a terminal, the text is returned as it is.
+func centered(text string) []string {
+	if !isTerminal {
+		return []string{text}
+	}
+	var lines []string
+	for _, line := range terminal.Wrap(text, width) {
+		lines = append(lines, terminal.Center(line, width))
+	}
+	return lines
+}
+
+// printRule prints an underlined line over the whole width.
+// In case stdout is not a terminal, nothing is printed.
+func printRule() {
+	if isTerminal {
+		fmt.Fprintln(stdout, au.Underline(au.Bold(strings.Repeat(" ", width))))
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/graph"
+	"github.com/spf13/cobra"
+)
+
+var revDepsDepth int
+var revDepsWorkers int
+var revDepsFlat bool
+
+var revDepsCmd = &cobra.Command{
+	Use:   "revdeps <atom>",
+	Short: "Show the reverse dependencies of a package transitively",
+	Long: `Follows the reverse dependencies of the given package, i.e. dev-lang/go,
+up to the given depth and prints them as a tree, or as a flat list using --flat.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if revDepsDepth < 1 {
+			return errors.New("--depth must be at least 1")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showReverseDependencies(args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func showReverseDependencies(atom string) error {
+	c := newClient()
+	fetch := func(ctx context.Context, revDepAtom string) ([]string, error) {
+		gpackage, err := c.GetPackage(ctx, revDepAtom, client.SectionDependencies)
+		if errors.Is(err, client.ErrNotFound) && revDepAtom != atom {
+			// reverse dependencies may refer to packages that have been removed
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		var revDeps []string
+		for _, revDep := range gpackage.ReverseDependencies {
+			revDeps = append(revDeps, revDep.ReverseDependencyAtom)
+		}
+		return revDeps, nil
+	}
+
+	root, err := graph.ReverseDependencies(context.Background(), atom, revDepsDepth, revDepsWorkers, fetch)
+	if err != nil {
+		return err
+	}
+
+	startPager()
+	defer stopPager()
+
+	if revDepsFlat {
+		for _, revDep := range root.Atoms() {
+			fmt.Fprintln(stdout, revDep)
+		}
+		return nil
+	}
+	printTree(root, 0)
+	return nil
+}
+
+// printTree prints the given node and its children indented by their level
+func printTree(node *graph.Node, level int) {
+	if level == 0 {
+		fmt.Fprintln(stdout, au.Bold(node.Atom))
+	} else {
+		fmt.Fprintln(stdout, strings.Repeat("  ", level)+node.Atom)
+	}
+	for _, child := range node.Children {
+		printTree(child, level+1)
+	}
+}
@@ -1,12 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/filter"
+	"github.com/arzano/pgo/pkg/graph"
+	"github.com/arzano/pgo/pkg/stringslice"
+	"github.com/arzano/pgo/pkg/view"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"os"
 	"strings"
+	"text/template"
+	"time"
 )
 
 var showBugs bool
@@ -16,28 +24,45 @@ var showQAreports bool
 var showDependencies bool
 var showMetadata bool
 var showVersions bool
+var showUpstream bool
+var longVersions bool
+var depsVersion string
 
 var searchPackageResults bool
 
+var archesFlag []string
+
+var bugFilter filter.BugFilter
+var pullRequestFilter filter.PullRequestFilter
+var pullRequestStale string
+var revDepTypes []string
+var commitFilter filter.CommitFilter
+var changelogSince string
+var changelogUntil string
+var changelogVersion string
+
+var outputFormat string
+var formatTemplate string
+var packageTemplate *template.Template
+
 var rootCmd = &cobra.Command{
 	Use:   "pgo [searchTerm or subcommand]",
 	Short: "pgo is a command line interface for packages.gentoo.org",
 	Long:  `Still TODO`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		// Do Stuff Here
-		showPackage(args[0], !searchPackageResults)
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		defaultSections()
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+		if err := parseFormatTemplate(); err != nil {
+			return err
+		}
+		return parseFilters(cmd)
 	},
-}
-
-var addCmd = &cobra.Command{
-	Use:   "maintainer",
-	Short: "Search for package maintainers",
-	Long:  `Still TODO`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Do Stuff Here
-		fmt.Println("Sub-cmd: " + strings.Join(args, ", "))
-
+		showPackage(args[0], !searchPackageResults)
 	},
 }
 
@@ -46,14 +71,34 @@ func Execute() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.Flags().BoolVarP(&searchPackageResults, "search", "s", viper.GetBool("packages.search"), "Search for packages")
-	rootCmd.Flags().BoolVarP(&showBugs, "bugs", "b", false, "Search bugs related to the packages")
-	rootCmd.Flags().BoolVarP(&showPullRequests, "pull-requests", "p", false, "Show pull requests for packages")
-	rootCmd.Flags().BoolVarP(&showChangelog, "changelog", "c", false, "Show changelog of the packages")
-	rootCmd.Flags().BoolVarP(&showQAreports, "qa-reports", "q", false, "Show QA report for packages")
-	rootCmd.Flags().BoolVarP(&showDependencies, "dependencies", "d", false, "Search dependencies of the packages")
-	rootCmd.Flags().BoolVarP(&showMetadata, "metadata", "m", false, "Show metadata of the packages")
-	rootCmd.Flags().BoolVarP(&showVersions, "versions", "v", false, "Show available versions of the packages")
-	rootCmd.AddCommand(addCmd)
+	addSectionFlags(rootCmd)
+	addFilterFlags(rootCmd)
+	rootCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format ("+strings.Join(outputFormats(), ", ")+")")
+	rootCmd.Flags().StringVar(&formatTemplate, "format", "", "Format the package using the given Go template")
+	rootCmd.PersistentFlags().StringSliceVar(&archesFlag, "arches", nil, "Comma-separated arches to show, or 'all' for the arches of the keywords")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "Colorize the output (auto, always, never)")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Do not pipe the output into a pager")
+
+	addSectionFlags(maintainerCmd)
+	addFilterFlags(maintainerCmd)
+
+	revDepsCmd.Flags().IntVar(&revDepsDepth, "depth", 1, "Number of levels of reverse dependencies to follow")
+	revDepsCmd.Flags().IntVarP(&revDepsWorkers, "jobs", "j", 8, "Number of concurrent lookups")
+	revDepsCmd.Flags().BoolVar(&revDepsFlat, "flat", false, "Print a deduplicated list instead of a tree")
+
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Graph format ("+strings.Join(graph.Formats, ", ")+")")
+	graphCmd.Flags().StringVar(&graphDirection, "direction", "both", "Dependencies to include (forward, reverse, both)")
+	graphCmd.Flags().StringVar(&graphVersion, "version", "latest", "Version whose dependencies are included")
+
+	outdatedCmd.Flags().StringVar(&outdatedMaintainer, "maintainer", "", "Only list packages of the given maintainer email or name")
+	outdatedCmd.Flags().StringVar(&outdatedCategory, "category", "", "Only list packages of the given category")
+
+	rootCmd.AddCommand(maintainerCmd)
+	rootCmd.AddCommand(categoryCmd)
+	rootCmd.AddCommand(useflagCmd)
+	rootCmd.AddCommand(outdatedCmd)
+	rootCmd.AddCommand(revDepsCmd)
+	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(completionCmd)
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -61,6 +106,36 @@ func Execute() {
 	}
 }
 
+// addSectionFlags adds the flags selecting the sections of the packages to cmd
+func addSectionFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&showBugs, "bugs", "b", false, "Search bugs related to the packages")
+	cmd.Flags().BoolVarP(&showPullRequests, "pull-requests", "p", false, "Show pull requests for packages")
+	cmd.Flags().BoolVarP(&showChangelog, "changelog", "c", false, "Show changelog of the packages")
+	cmd.Flags().BoolVarP(&showQAreports, "qa-reports", "q", false, "Show QA report for packages")
+	cmd.Flags().BoolVarP(&showDependencies, "dependencies", "d", false, "Search dependencies of the packages")
+	cmd.Flags().BoolVarP(&showMetadata, "metadata", "m", false, "Show metadata of the packages")
+	cmd.Flags().BoolVarP(&showVersions, "versions", "v", false, "Show available versions of the packages")
+	cmd.Flags().BoolVarP(&showUpstream, "upstream", "u", false, "Show newer versions released by upstream")
+	cmd.Flags().StringVar(&depsVersion, "deps", "", "Show the dependencies of the given version, or of the newest version if none is given")
+	cmd.Flags().Lookup("deps").NoOptDefVal = "latest"
+	cmd.Flags().BoolVarP(&longVersions, "long", "l", false, "Show slot, subslot, EAPI, restricts and properties of the versions")
+}
+
+// addFilterFlags adds the flags narrowing down the sections of the packages to cmd
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&bugFilter.Statuses, "bug-status", nil, "Only show bugs with the given status, i.e. CONFIRMED")
+	cmd.Flags().StringSliceVar(&bugFilter.Components, "bug-component", nil, "Only show bugs of the given component, i.e. Vulnerabilities")
+	cmd.Flags().StringVar(&pullRequestFilter.CiState, "pr-ci", "", "Only show pull requests with the given CI state, i.e. success or failure")
+	cmd.Flags().StringSliceVar(&pullRequestFilter.Labels, "pr-label", nil, "Only show pull requests with the given labels")
+	cmd.Flags().StringVar(&pullRequestStale, "pr-stale", "", "Only show pull requests that have not been updated for the given time, i.e. 30d")
+	cmd.Flags().StringSliceVar(&revDepTypes, "revdep-type", nil, "Only show reverse dependencies of the given type, i.e. rdepend")
+	cmd.Flags().IntVar(&commitFilter.Limit, "changelog-limit", 15, "Maximum number of commits in the changelog, 0 for all commits")
+	cmd.Flags().StringVar(&changelogSince, "since", "", "Only show commits since the given date, i.e. 2020-06-01 or 30d")
+	cmd.Flags().StringVar(&changelogUntil, "until", "", "Only show commits until the given date, i.e. 2020-06-30 or 30d")
+	cmd.Flags().StringVar(&commitFilter.Author, "author", "", "Only show commits of the given author name or email address")
+	cmd.Flags().StringVar(&changelogVersion, "for-version", "", "Only show commits touching the given version, or 'latest'")
+}
+
 func initConfig() {
 	// Find home directory.
 	home, err := homedir.Dir()
@@ -81,11 +156,20 @@ func initConfig() {
 
 	setViperDefaults()
 
-	if !(showBugs || showPullRequests || showChangelog || showQAreports || showDependencies || showMetadata || showVersions) {
+	if err := setupRendering(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+}
+
+// defaultSections shows all sections of a package if none have been selected
+func defaultSections() {
+	if !(showBugs || showPullRequests || showChangelog || showQAreports || showDependencies || showMetadata || showVersions || showUpstream || depsVersion != "") {
 		if viper.GetString("packages.defaultView") == "full" {
-			showBugs, showPullRequests, showChangelog, showQAreports, showDependencies, showMetadata, showVersions = true, true, true, true, true, true, true
+			showBugs, showPullRequests, showChangelog, showQAreports, showDependencies, showMetadata, showVersions, showUpstream = true, true, true, true, true, true, true, true
 		} else {
-			showBugs, showPullRequests, showChangelog, showQAreports, showDependencies, showMetadata, showVersions = true, true, true, true, true, true, true
+			showBugs, showPullRequests, showChangelog, showQAreports, showDependencies, showMetadata, showVersions, showUpstream = true, true, true, true, true, true, true, true
 		}
 	}
 }
@@ -93,4 +177,87 @@ func initConfig() {
 func setViperDefaults() {
 	viper.SetDefault("packages.defaultView", "full")
 	viper.SetDefault("packages.search", false)
+	viper.SetDefault("api.endpoint", client.DefaultEndpoint)
+	viper.SetDefault("packages.arches", view.DefaultArches)
+	viper.SetDefault("pager", "less -R")
+}
+
+// outputFormats returns all supported output formats
+func outputFormats() []string {
+	return append([]string{"text"}, view.Formats...)
+}
+
+// validateOutputFormat returns an error if the selected output format is not supported
+func validateOutputFormat() error {
+	for _, format := range outputFormats() {
+		if outputFormat == format {
+			return nil
+		}
+	}
+	return errors.New("unknown output format '" + outputFormat + "', expected one of: " + strings.Join(outputFormats(), ", "))
+}
+
+// parseFilters parses the filter flags that are not simple strings
+func parseFilters(cmd *cobra.Command) error {
+	if pullRequestStale != "" {
+		staleFor, err := filter.ParseAge(pullRequestStale)
+		if err != nil {
+			return err
+		}
+		pullRequestFilter.StaleFor = staleFor
+	}
+	if changelogSince != "" {
+		since, err := filter.ParseDate(changelogSince, time.Now())
+		if err != nil {
+			return err
+		}
+		commitFilter.Since = since
+	}
+	if changelogUntil != "" {
+		until, err := filter.ParseDate(changelogUntil, time.Now())
+		if err != nil {
+			return err
+		}
+		// include the whole day if a date is given
+		if _, err := time.Parse("2006-01-02", changelogUntil); err == nil {
+			until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		commitFilter.Until = until
+	}
+	if commitFilter.Limit < 0 {
+		return errors.New("--changelog-limit must not be negative")
+	}
+	// json, yaml and toml contain the full changelog by default, while
+	// reports and templates are limited like the terminal view
+	if stringslice.Contains([]string{"json", "yaml", "toml"}, outputFormat) && !cmd.Flags().Changed("changelog-limit") {
+		commitFilter.Limit = 0
+	}
+	return nil
+}
+
+// parseFormatTemplate parses the template given by --format
+func parseFormatTemplate() error {
+	if formatTemplate == "" {
+		return nil
+	}
+	if outputFormat != "text" {
+		return errors.New("--format can't be combined with --output " + outputFormat)
+	}
+	tmpl, err := view.NewTemplate(formatTemplate)
+	if err != nil {
+		return err
+	}
+	packageTemplate = tmpl
+	return nil
+}
+
+// machineReadable returns true if the output is
+// going to be processed by other programs
+func machineReadable() bool {
+	return outputFormat != "text" || packageTemplate != nil
+}
+
+// newClient creates a client for the configured API endpoint
+func newClient() *client.Client {
+	return client.NewClient(viper.GetString("api.endpoint"))
 }
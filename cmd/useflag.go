@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/arzano/pgo/pkg/stringslice"
+	"github.com/spf13/cobra"
+)
+
+var useflagCmd = &cobra.Command{
+	Use:   "useflag <flag>",
+	Short: "Show the descriptions of a USE flag",
+	Long: `Shows the global and local descriptions of the given USE flag, i.e. gccgo,
+its USE_EXPAND group, and lists the packages declaring it together with their
+local description, if any.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showUseflag(args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func showUseflag(name string) error {
+	c := newClient()
+	useflags, err := c.GetUseflags(context.Background(), name)
+	if err != nil {
+		return err
+	}
+	versions, err := c.FindUseflagVersions(context.Background(), name)
+	if err != nil {
+		return err
+	}
+	if len(useflags) == 0 && len(versions) == 0 {
+		return errors.New("No USE flag found for '" + name + "'")
+	}
+
+	var global, local, useExpand []models.Useflag
+	for _, useflag := range useflags {
+		switch {
+		case useflag.UseExpand != "":
+			useExpand = append(useExpand, useflag)
+		case useflag.Package != "":
+			local = append(local, useflag)
+		default:
+			global = append(global, useflag)
+		}
+	}
+
+	// the packages declaring the flag, with their local description if any
+	descriptions := map[string]string{}
+	var packages []string
+	for _, useflag := range local {
+		descriptions[useflag.Package] = useflag.Description
+		packages = append(packages, useflag.Package)
+	}
+	for _, version := range versions {
+		packages = append(packages, version.Atom)
+	}
+	packages = stringslice.Unique(packages)
+
+	startPager()
+	defer stopPager()
+
+	fmt.Fprintln(stdout)
+	printRule()
+	fmt.Fprintln(stdout)
+	for _, line := range centered(name) {
+		fmt.Fprintln(stdout, au.Bold(line))
+	}
+	fmt.Fprintln(stdout)
+
+	if len(global) > 0 {
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Global"))))
+		for _, useflag := range global {
+			fmt.Fprintln(stdout, "  "+useflag.Description)
+		}
+		fmt.Fprintln(stdout)
+	}
+
+	if len(useExpand) > 0 {
+		fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("USE_EXPAND"))))
+		for _, useflag := range useExpand {
+			fmt.Fprintln(stdout, "  "+au.Bold(strings.ToUpper(useflag.UseExpand)).String()+": "+useflag.Description)
+		}
+		fmt.Fprintln(stdout)
+	}
+
+	fmt.Fprintln(stdout, au.Underline(au.Bold(au.Green("Packages"))))
+	if len(packages) == 0 {
+		fmt.Fprintln(stdout, "  No packages")
+		return nil
+	}
+	maxLength := 0
+	for _, atom := range packages {
+		maxLength = max(maxLength, len(atom))
+	}
+	for _, atom := range packages {
+		if description, ok := descriptions[atom]; ok {
+			fmt.Fprintln(stdout, "  "+au.Bold(atom).String()+strings.Repeat(" ", maxLength-len(atom))+"  "+description)
+		} else {
+			fmt.Fprintln(stdout, "  "+au.Bold(atom).String())
+		}
+	}
+	return nil
+}
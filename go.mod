@@ -7,7 +7,10 @@ require (
 	github.com/machinebox/graphql v0.2.2
 	github.com/matryer/is v1.4.0 // indirect
 	github.com/mitchellh/go-homedir v1.1.0
+	github.com/pelletier/go-toml v1.2.0
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/viper v1.4.0
+	golang.org/x/sys v0.0.0-20190215142949-d0b11bdaac8a
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -0,0 +1,246 @@
+// Contains a client for the packages.gentoo.org GraphQL API
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/machinebox/graphql"
+)
+
+// DefaultEndpoint is the GraphQL API of packages.gentoo.org
+const DefaultEndpoint = "https://packages.gentoo.org/api/graphql/"
+
+// ErrNotFound is returned if the requested object does not exist
+var ErrNotFound = errors.New("not found")
+
+// Client queries the packages.gentoo.org GraphQL API.
+// It is safe to share a Client across requests.
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+	graphql    *graphql.Client
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client that is used to issue requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a new Client for the given endpoint. In
+// case the endpoint is empty, the DefaultEndpoint is used.
+func NewClient(endpoint string, opts ...Option) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	c := &Client{endpoint: endpoint}
+	for _, opt := range opts {
+		opt(c)
+	}
+	var graphqlOpts []graphql.ClientOption
+	if c.httpClient != nil {
+		graphqlOpts = append(graphqlOpts, graphql.WithHTTPClient(c.httpClient))
+	}
+	c.graphql = graphql.NewClient(endpoint, graphqlOpts...)
+	return c
+}
+
+// Endpoint returns the endpoint the client is talking to
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// SearchPackages returns at most resultSize packages matching the given
+// search term. Only the given sections of the packages are fetched.
+func (c *Client) SearchPackages(ctx context.Context, searchTerm string, resultSize int, sections Section) ([]models.Package, error) {
+	req := graphql.NewRequest(buildSearchQuery(sections))
+	req.Var("searchTerm", searchTerm)
+	req.Var("resultSize", resultSize)
+
+	var respData struct {
+		PackageSearch []models.Package
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.PackageSearch, nil
+}
+
+// GetPackage returns the package with the given atom, i.e. 'dev-lang/go'.
+// Only the given sections of the package are fetched.
+func (c *Client) GetPackage(ctx context.Context, atom string, sections Section) (models.Package, error) {
+	req := graphql.NewRequest(buildGetPackageQuery(sections))
+	req.Var("atom", atom)
+
+	var respData struct {
+		Package *models.Package
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return models.Package{}, err
+	}
+	if respData.Package == nil {
+		return models.Package{}, fmt.Errorf("package %s: %w", atom, ErrNotFound)
+	}
+	return *respData.Package, nil
+}
+
+// GetMaintainer returns the maintainer with the given email address
+func (c *Client) GetMaintainer(ctx context.Context, email string) (models.Maintainer, error) {
+	req := graphql.NewRequest(maintainerQuery)
+	req.Var("email", email)
+
+	var respData struct {
+		Maintainer *models.Maintainer
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return models.Maintainer{}, err
+	}
+	if respData.Maintainer == nil {
+		return models.Maintainer{}, fmt.Errorf("maintainer %s: %w", email, ErrNotFound)
+	}
+	return *respData.Maintainer, nil
+}
+
+// FindMaintainers returns the maintainers with the given name, i.e. 'Go Project'
+func (c *Client) FindMaintainers(ctx context.Context, name string) ([]models.Maintainer, error) {
+	req := graphql.NewRequest(maintainersQuery)
+	req.Var("name", name)
+
+	var respData struct {
+		Maintainers []models.Maintainer
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Maintainers, nil
+}
+
+// GetMaintainerPackages returns the packages maintained by the maintainer with
+// the given email address. Only the given sections of the packages are fetched.
+func (c *Client) GetMaintainerPackages(ctx context.Context, email string, sections Section) ([]models.Package, error) {
+	req := graphql.NewRequest(buildMaintainerPackagesQuery(sections))
+	req.Var("email", email)
+
+	var respData struct {
+		Packages []models.Package
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Packages, nil
+}
+
+// GetCategory returns the category with the given name, including its packages
+func (c *Client) GetCategory(ctx context.Context, name string) (models.Category, error) {
+	req := graphql.NewRequest(categoryQuery)
+	req.Var("name", name)
+
+	var respData struct {
+		Category *models.Category
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return models.Category{}, err
+	}
+	if respData.Category == nil {
+		return models.Category{}, fmt.Errorf("category %s: %w", name, ErrNotFound)
+	}
+	return *respData.Category, nil
+}
+
+// GetCategories returns all categories without their packages
+func (c *Client) GetCategories(ctx context.Context) ([]models.Category, error) {
+	req := graphql.NewRequest(categoriesQuery)
+
+	var respData struct {
+		Categories []models.Category
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Categories, nil
+}
+
+// GetUseflags returns the global, local and USE_EXPAND
+// descriptions of the USE flag with the given name
+func (c *Client) GetUseflags(ctx context.Context, name string) ([]models.Useflag, error) {
+	req := graphql.NewRequest(useflagsQuery)
+	req.Var("name", name)
+
+	var respData struct {
+		Useflags []models.Useflag
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Useflags, nil
+}
+
+// FindUseflagVersions returns the versions of all packages that
+// declare the given USE flag in their IUSE, i.e. 'doc'
+func (c *Client) FindUseflagVersions(ctx context.Context, name string) ([]models.Version, error) {
+	req := graphql.NewRequest(useflagVersionsQuery)
+	req.Var("name", name)
+
+	var respData struct {
+		Versions []models.Version
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Versions, nil
+}
+
+// GetOutdatedPackages returns all packages that are
+// behind the newest version released by upstream
+func (c *Client) GetOutdatedPackages(ctx context.Context) ([]models.OutdatedPackages, error) {
+	req := graphql.NewRequest(outdatedPackagesQuery)
+
+	var respData struct {
+		OutdatedPackages []models.OutdatedPackages
+	}
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	return respData.OutdatedPackages, nil
+}
+
+// LookupUseflags returns the global, local and USE_EXPAND descriptions
+// of all given USE flags using a single request
+func (c *Client) LookupUseflags(ctx context.Context, names []string) ([]models.Useflag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	req := graphql.NewRequest(buildUseflagsQuery(len(names)))
+	for i, name := range names {
+		req.Var("name"+strconv.Itoa(i), name)
+	}
+
+	var respData map[string][]models.Useflag
+	if err := c.run(ctx, req, &respData); err != nil {
+		return nil, err
+	}
+	var useflags []models.Useflag
+	for i := range names {
+		useflags = append(useflags, respData["useflags"+strconv.Itoa(i)]...)
+	}
+	return useflags, nil
+}
+
+// run executes the given request and decodes the response into resp
+func (c *Client) run(ctx context.Context, req *graphql.Request, resp interface{}) error {
+	req.Header.Set("Cache-Control", "no-cache")
+	if err := c.graphql.Run(ctx, req, resp); err != nil {
+		return fmt.Errorf("querying %s: %w", c.endpoint, err)
+	}
+	return nil
+}
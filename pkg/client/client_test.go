@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// graphqlRequest is the body the client sends to the server
+type graphqlRequest struct {
+	Query     string
+	Variables map[string]interface{}
+}
+
+// newTestServer returns a server that records the last request and answers with the given data
+func newTestServer(t *testing.T, data string, last *graphqlRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(last); err != nil {
+			t.Errorf("decoding request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": ` + data + `}`))
+	}))
+}
+
+func TestClient_SearchPackages(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"packageSearch": [{"Atom": "dev-lang/go", "Versions": [{"Version": "1.14.4"}]}]}`, &req)
+	defer server.Close()
+
+	packages, err := NewClient(server.URL).SearchPackages(context.Background(), "go", 1, AllSections)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(packages) != 1 || packages[0].Atom != "dev-lang/go" {
+		t.Errorf("got %v, want [dev-lang/go]", packages)
+	}
+	if len(packages[0].Versions) != 1 || packages[0].Versions[0].Version != "1.14.4" {
+		t.Errorf("got versions %v, want [1.14.4]", packages[0].Versions)
+	}
+	if req.Variables["searchTerm"] != "go" || req.Variables["resultSize"] != float64(1) {
+		t.Errorf("got variables %v, want searchTerm=go and resultSize=1", req.Variables)
+	}
+}
+
+func TestClient_SearchPackages_Escaping(t *testing.T) {
+	var tests = []string{
+		`go"`,
+		`go\`,
+		`"), evil: packages(Atom: "x`,
+	}
+
+	for _, searchTerm := range tests {
+		t.Run(searchTerm, func(t *testing.T) {
+			var req graphqlRequest
+			server := newTestServer(t, `{"packageSearch": []}`, &req)
+			defer server.Close()
+
+			if _, err := NewClient(server.URL).SearchPackages(context.Background(), searchTerm, 10, AllSections); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Contains(req.Query, searchTerm) {
+				t.Errorf("search term %q has been spliced into the query", searchTerm)
+			}
+			if req.Variables["searchTerm"] != searchTerm {
+				t.Errorf("got %v, want %s", req.Variables["searchTerm"], searchTerm)
+			}
+		})
+	}
+}
+
+func TestClient_GetPackage(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"package": {"Atom": "dev-lang/go", "Name": "go"}}`, &req)
+	defer server.Close()
+
+	gpackage, err := NewClient(server.URL).GetPackage(context.Background(), "dev-lang/go", AllSections)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gpackage.Name != "go" {
+		t.Errorf("got %s, want go", gpackage.Name)
+	}
+	if req.Variables["atom"] != "dev-lang/go" {
+		t.Errorf("got atom variable %v, want dev-lang/go", req.Variables["atom"])
+	}
+}
+
+func TestClient_GetMaintainerPackages(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"packages": [{"Atom": "dev-lang/go"}, {"Atom": "dev-go/a"}]}`, &req)
+	defer server.Close()
+
+	packages, err := NewClient(server.URL).GetMaintainerPackages(context.Background(), "go@gentoo.org", SectionBugs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(packages) != 2 || packages[1].Atom != "dev-go/a" {
+		t.Errorf("got %v, want [dev-lang/go dev-go/a]", packages)
+	}
+	if req.Variables["email"] != "go@gentoo.org" {
+		t.Errorf("got email variable %v, want go@gentoo.org", req.Variables["email"])
+	}
+	if !strings.Contains(req.Query, "...BugsSection") || strings.Contains(req.Query, "...ChangelogSection") {
+		t.Errorf("query does not only request the bugs section:\n%s", req.Query)
+	}
+}
+
+func TestClient_FindMaintainers(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"maintainers": [{"Name": "Go Project", "Email": "go@gentoo.org", "Type": "project"}]}`, &req)
+	defer server.Close()
+
+	maintainers, err := NewClient(server.URL).FindMaintainers(context.Background(), `Go "Project"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(maintainers) != 1 || maintainers[0].Email != "go@gentoo.org" {
+		t.Errorf("got %v, want [go@gentoo.org]", maintainers)
+	}
+	if req.Variables["name"] != `Go "Project"` || strings.Contains(req.Query, "Project") {
+		t.Errorf("name has not been passed as variable: %v\n%s", req.Variables, req.Query)
+	}
+}
+
+func TestClient_GetCategories(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"categories": [{"Name": "dev-lang", "Description": "Programming languages"}]}`, &req)
+	defer server.Close()
+
+	categories, err := NewClient(server.URL).GetCategories(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(categories) != 1 || categories[0].Description != "Programming languages" {
+		t.Errorf("got %v, want [dev-lang]", categories)
+	}
+}
+
+func TestClient_GetUseflags(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"useflags": [{"Name": "gccgo", "Scope": "local", "Package": "dev-lang/go"}]}`, &req)
+	defer server.Close()
+
+	useflags, err := NewClient(server.URL).GetUseflags(context.Background(), "gccgo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(useflags) != 1 || useflags[0].Package != "dev-lang/go" {
+		t.Errorf("got %v, want a local flag of dev-lang/go", useflags)
+	}
+	if req.Variables["name"] != "gccgo" {
+		t.Errorf("got name variable %v, want gccgo", req.Variables["name"])
+	}
+}
+
+func TestClient_FindUseflagVersions(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"versions": [{"Atom": "dev-lang/go", "Version": "1.14.4"}, {"Atom": "dev-go/a", "Version": "1.0"}]}`, &req)
+	defer server.Close()
+
+	versions, err := NewClient(server.URL).FindUseflagVersions(context.Background(), "doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[1].Atom != "dev-go/a" {
+		t.Errorf("got %v, want versions of dev-lang/go and dev-go/a", versions)
+	}
+	if req.Variables["name"] != "doc" || !strings.Contains(req.Query, "versions(Useflag: $name)") {
+		t.Errorf("got name variable %v, want doc passed as $name:\n%s", req.Variables["name"], req.Query)
+	}
+}
+
+func TestClient_LookupUseflags(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"useflags0": [{"Name": "gccgo", "Package": "dev-lang/go"}], "useflags1": [{"Name": "doc", "Scope": "global"}]}`, &req)
+	defer server.Close()
+
+	names := []string{"gccgo", `doc") { Id } evil: useflags(Name: "x`}
+	useflags, err := NewClient(server.URL).LookupUseflags(context.Background(), names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(useflags) != 2 || useflags[0].Name != "gccgo" || useflags[1].Name != "doc" {
+		t.Errorf("got %v, want gccgo and doc", useflags)
+	}
+	if req.Variables["name0"] != names[0] || req.Variables["name1"] != names[1] {
+		t.Errorf("got variables %v, want %v", req.Variables, names)
+	}
+	if strings.Contains(req.Query, "evil") {
+		t.Errorf("USE flag has been spliced into the query:\n%s", req.Query)
+	}
+}
+
+func TestClient_GetOutdatedPackages(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"outdatedPackages": [{"Atom": "dev-lang/go", "GentooVersion": "1.14.4", "NewestVersion": "1.15"}]}`, &req)
+	defer server.Close()
+
+	outdated, err := NewClient(server.URL).GetOutdatedPackages(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(outdated) != 1 || outdated[0].NewestVersion != "1.15" {
+		t.Errorf("got %v, want dev-lang/go 1.15", outdated)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	var req graphqlRequest
+	server := newTestServer(t, `{"package": null, "maintainer": null, "category": null}`, &req)
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	ctx := context.Background()
+
+	if _, err := c.GetPackage(ctx, "dev-lang/nonexistent", AllSections); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPackage: got %v, want ErrNotFound", err)
+	}
+	if _, err := c.GetMaintainer(ctx, "nobody@gentoo.org"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetMaintainer: got %v, want ErrNotFound", err)
+	}
+	if _, err := c.GetCategory(ctx, "dev-nonexistent"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetCategory: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errors": [{"message": "something went wrong"}]}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL).GetMaintainer(context.Background(), "dev@gentoo.org"); err == nil {
+		t.Errorf("got no error, want an error")
+	}
+}
+
+func TestNewClient_DefaultEndpoint(t *testing.T) {
+	if got := NewClient("").Endpoint(); got != DefaultEndpoint {
+		t.Errorf("got %s, want %s", got, DefaultEndpoint)
+	}
+}
@@ -0,0 +1,319 @@
+// Contains the GraphQL queries used by the client.
+//
+// All queries are declared operations. User supplied input is never
+// spliced into the query text, but passed using variables instead.
+
+package client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Section is a part of a package that can be requested
+type Section uint
+
+const (
+	SectionVersions Section = 1 << iota
+	SectionMetadata
+	SectionBugs
+	SectionPullRequests
+	SectionQAReports
+	SectionDependencies
+	SectionChangelog
+	SectionForwardDependencies
+	SectionUpstream
+	// SectionVersionCommits requests the commits touching each version,
+	// which is only needed to narrow down the changelog to a version
+	SectionVersionCommits
+
+	// AllSections requests every part of a package
+	AllSections = SectionVersions | SectionMetadata | SectionBugs | SectionPullRequests |
+		SectionQAReports | SectionDependencies | SectionChangelog | SectionForwardDependencies |
+		SectionUpstream | SectionVersionCommits
+)
+
+// fragment is a named selection set on a package
+type fragment struct {
+	name   string
+	fields string
+}
+
+// headerFragment is always requested, as it is needed to display any package
+var headerFragment = fragment{"PackageHeader", `
+	  Atom,
+	  Category,
+	  Name,
+	  Versions {
+	    Version,
+	    Description,
+	    Homepage,
+	    License
+	  }`}
+
+// sectionFragments contains the selection set of each section
+var sectionFragments = []struct {
+	section Section
+	fragment
+}{
+	{SectionVersions, fragment{"VersionsSection", `
+	  Versions {
+	    Version,
+	    Slot,
+	    Subslot,
+	    EAPI,
+	    Restricts,
+	    Properties,
+	    Keywords,
+	    Masks {
+	      Versions,
+	      Author,
+	      AuthorEmail,
+	      Date,
+	      Reason
+	    }
+	  }`}},
+	{SectionMetadata, fragment{"MetadataSection", `
+	  Longdescription,
+	  Maintainers {
+	    Name,
+	    Email
+	  },
+	  Versions {
+	    Version,
+	    License,
+	    Useflags
+	  }`}},
+	{SectionBugs, fragment{"BugsSection", `
+	  Bugs {
+	    Id,
+	    Summary,
+	    Product,
+	    Component,
+	    Assignee,
+	    Status
+	  }`}},
+	{SectionPullRequests, fragment{"PullRequestsSection", `
+	  PullRequests {
+	    Id,
+	    Title,
+	    Author,
+	    Url,
+	    CiState,
+	    CiStateLink,
+	    Labels {
+	      Name,
+	      Color
+	    },
+	    Comments,
+	    Files {
+	      Path,
+	      Additions,
+	      Deletions
+	    },
+	    CreatedAt,
+	    UpdatedAt
+	  }`}},
+	{SectionQAReports, fragment{"QAReportsSection", `
+	  PkgCheckResults {
+	    Class,
+	    Message
+	  },
+	  Versions {
+	    Version,
+	    PkgCheckResults {
+	      Class,
+	      Message
+	    }
+	  }`}},
+	{SectionDependencies, fragment{"DependenciesSection", `
+	  ReverseDependencies {
+	    ReverseDependencyAtom,
+	    ReverseDependencyVersion,
+	    Type,
+	    Condition
+	  }`}},
+	{SectionForwardDependencies, fragment{"ForwardDependenciesSection", `
+	  Versions {
+	    Version,
+	    Dependencies {
+	      Atom,
+	      Type,
+	      Condition
+	    }
+	  }`}},
+	{SectionUpstream, fragment{"UpstreamSection", `
+	  Outdated {
+	    GentooVersion,
+	    NewestVersion
+	  }`}},
+	{SectionChangelog, fragment{"ChangelogSection", `
+	  Commits {
+	    Id,
+	    AuthorName,
+	    AuthorEmail,
+	    CommitterName,
+	    CommitterEmail,
+	    Message,
+	    PrecedingCommits,
+	    CommitterDate
+	  }`}},
+	{SectionVersionCommits, fragment{"VersionCommitsSection", `
+	  Versions {
+	    Version,
+	    Commits {
+	      Id
+	    }
+	  }`}},
+}
+
+// buildPackageQuery creates a query for the given operation, i.e.
+//   query ($atom: String!)
+// and field, i.e.
+//   package(Atom: $atom)
+// that only requests the fragments of the given sections.
+func buildPackageQuery(operation, field string, sections Section) string {
+	fragments := []fragment{headerFragment}
+	for _, sectionFragment := range sectionFragments {
+		if sections&sectionFragment.section != 0 {
+			fragments = append(fragments, sectionFragment.fragment)
+		}
+	}
+
+	var query strings.Builder
+	query.WriteString(operation + " {\n  " + field + " {\n")
+	for _, f := range fragments {
+		query.WriteString("    ..." + f.name + "\n")
+	}
+	query.WriteString("  }\n}\n")
+	for _, f := range fragments {
+		query.WriteString("\nfragment " + f.name + " on Package {" + f.fields + "\n}\n")
+	}
+	return query.String()
+}
+
+func buildSearchQuery(sections Section) string {
+	return buildPackageQuery(
+		"query ($searchTerm: String!, $resultSize: Int)",
+		"packageSearch(searchTerm: $searchTerm, resultSize: $resultSize)",
+		sections)
+}
+
+func buildGetPackageQuery(sections Section) string {
+	return buildPackageQuery(
+		"query ($atom: String!)",
+		"package(Atom: $atom)",
+		sections)
+}
+
+func buildMaintainerPackagesQuery(sections Section) string {
+	return buildPackageQuery(
+		"query ($email: String!)",
+		"packages(Maintainer: $email)",
+		sections)
+}
+
+const maintainerQuery = `
+	query ($email: String!) {
+	  maintainer(Email: $email) {
+		Name,
+		Email,
+		Type,
+		Restrict,
+		PackagesInformation {
+		  Outdated,
+		  PullRequests,
+		  Bugs,
+		  SecurityBugs
+		}
+	  }
+	}
+	`
+
+const maintainersQuery = `
+	query ($name: String!) {
+	  maintainers(Name: $name) {
+		Name,
+		Email,
+		Type
+	  }
+	}
+	`
+
+const categoryQuery = `
+	query ($name: String!) {
+	  category(Name: $name) {
+		Name,
+		Description,
+		Packages {
+		  Atom,
+		  Name,
+		  Versions {
+		    Version,
+		    Keywords,
+		    Description
+		  }
+		}
+	  }
+	}
+	`
+
+const categoriesQuery = `
+	query {
+	  categories {
+		Name,
+		Description
+	  }
+	}
+	`
+
+// buildUseflagsQuery creates a query that looks up the given
+// number of USE flags at once, passed as $name0, $name1, ...
+// The results of each flag are aliased as useflags0, useflags1, ...
+func buildUseflagsQuery(count int) string {
+	var query strings.Builder
+	query.WriteString("query (")
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("$name" + strconv.Itoa(i) + ": String!")
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < count; i++ {
+		query.WriteString("  useflags" + strconv.Itoa(i) + ": useflags(Name: $name" + strconv.Itoa(i) + ") {\n    ...UseflagFields\n  }\n")
+	}
+	query.WriteString("}\n\nfragment UseflagFields on Useflag {\n  Name,\n  Scope,\n  Description,\n  UseExpand,\n  Package\n}\n")
+	return query.String()
+}
+
+const useflagsQuery = `
+	query ($name: String!) {
+	  useflags(Name: $name) {
+		Name,
+		Scope,
+		Description,
+		UseExpand,
+		Package
+	  }
+	}
+	`
+
+const useflagVersionsQuery = `
+	query ($name: String!) {
+	  versions(Useflag: $name) {
+		Atom,
+		Version
+	  }
+	}
+	`
+
+const outdatedPackagesQuery = `
+	query {
+	  outdatedPackages {
+		Atom,
+		GentooVersion,
+		NewestVersion
+	  }
+	}
+	`
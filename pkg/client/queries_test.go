@@ -0,0 +1,57 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildPackageQuery(t *testing.T) {
+	var tests = []struct {
+		name     string
+		sections Section
+		want     []string
+		notWant  []string
+	}{
+		{"versions only", SectionVersions,
+			[]string{"...PackageHeader", "...VersionsSection", "Keywords"},
+			[]string{"Commits", "ReverseDependencies", "Bugs", "PullRequests", "PkgCheckResults", "Maintainers"}},
+		{"bugs and changelog", SectionBugs | SectionChangelog,
+			[]string{"...PackageHeader", "...BugsSection", "...ChangelogSection"},
+			[]string{"...VersionsSection", "...VersionCommitsSection", "ReverseDependencies", "PullRequests"}},
+		{"changelog of a version", SectionChangelog | SectionVersionCommits,
+			[]string{"...ChangelogSection", "...VersionCommitsSection"},
+			[]string{"...VersionsSection", "Bugs"}},
+		{"no sections", 0,
+			[]string{"...PackageHeader"},
+			[]string{"Section"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := buildGetPackageQuery(tt.sections)
+			for _, want := range tt.want {
+				if !strings.Contains(query, want) {
+					t.Errorf("query does not contain %s:\n%s", want, query)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(query, notWant) {
+					t.Errorf("query contains %s:\n%s", notWant, query)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildPackageQuery_FragmentsAreUsed(t *testing.T) {
+	query := buildSearchQuery(AllSections)
+	names := []string{headerFragment.name}
+	for _, sectionFragment := range sectionFragments {
+		names = append(names, sectionFragment.name)
+	}
+	for _, name := range names {
+		if !strings.Contains(query, "..."+name) || !strings.Contains(query, "fragment "+name+" on Package") {
+			t.Errorf("fragment %s is not both used and declared", name)
+		}
+	}
+}
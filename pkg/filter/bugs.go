@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// BugFilter selects bugs. Empty fields match all bugs.
+type BugFilter struct {
+	// Statuses are the accepted statuses, i.e. 'CONFIRMED'
+	Statuses []string
+	// Components are the accepted components, i.e. 'Vulnerabilities'
+	Components []string
+}
+
+// Bugs returns the bugs that match the given filter
+func Bugs(bugs []*models.Bug, f BugFilter) []*models.Bug {
+	var filtered []*models.Bug
+	for _, bug := range bugs {
+		if matchesAny(bug.Status, f.Statuses) && matchesAny(bug.Component, f.Components) {
+			filtered = append(filtered, bug)
+		}
+	}
+	return filtered
+}
+
+// matchesAny returns true if the value equals any of the
+// given values ignoring the case, or if none are given
+func matchesAny(value string, values []string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
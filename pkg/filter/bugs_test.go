@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestBugs(t *testing.T) {
+	bugs := []*models.Bug{
+		{Id: "1", Status: "CONFIRMED", Component: "Vulnerabilities"},
+		{Id: "2", Status: "UNCONFIRMED", Component: "Current packages"},
+		{Id: "3", Status: "IN_PROGRESS", Component: "Vulnerabilities"},
+	}
+
+	var tests = []struct {
+		name   string
+		filter BugFilter
+		want   string
+	}{
+		{"no filter", BugFilter{}, "1 2 3"},
+		{"status", BugFilter{Statuses: []string{"confirmed"}}, "1"},
+		{"statuses", BugFilter{Statuses: []string{"CONFIRMED", "UNCONFIRMED"}}, "1 2"},
+		{"component", BugFilter{Components: []string{"Vulnerabilities"}}, "1 3"},
+		{"combined", BugFilter{Statuses: []string{"IN_PROGRESS"}, Components: []string{"Vulnerabilities"}}, "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, bug := range Bugs(bugs, tt.filter) {
+				ids = append(ids, bug.Id)
+			}
+			if ret := strings.Join(ids, " "); ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// CommitFilter selects commits. Empty fields match all commits.
+type CommitFilter struct {
+	// Since is the earliest accepted commit date
+	Since time.Time
+	// Until is the latest accepted commit date
+	Until time.Time
+	// Author is part of the name or email address of the author or committer
+	Author string
+	// Limit is the maximum number of commits, 0 means no limit
+	Limit int
+}
+
+// Commits returns the newest commits that match the given filter
+func Commits(commits []*models.Commit, f CommitFilter) []*models.Commit {
+	sorted := append([]*models.Commit{}, commits...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PrecedingCommits > sorted[j].PrecedingCommits
+	})
+
+	var filtered []*models.Commit
+	for _, commit := range sorted {
+		if f.Limit > 0 && len(filtered) == f.Limit {
+			break
+		}
+		if f.matches(commit) {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
+
+func (f CommitFilter) matches(commit *models.Commit) bool {
+	if !f.Since.IsZero() && commit.CommitterDate.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && commit.CommitterDate.After(f.Until) {
+		return false
+	}
+	if f.Author != "" {
+		author := strings.ToLower(f.Author)
+		for _, field := range []string{commit.AuthorName, commit.AuthorEmail, commit.CommitterName, commit.CommitterEmail} {
+			if strings.Contains(strings.ToLower(field), author) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// VersionCommits returns the commits that touched the given version
+func VersionCommits(commits []*models.Commit, version *models.Version) []*models.Commit {
+	if version == nil {
+		return nil
+	}
+	touched := map[string]bool{}
+	for _, commit := range version.Commits {
+		touched[commit.Id] = true
+	}
+	var filtered []*models.Commit
+	for _, commit := range commits {
+		if touched[commit.Id] {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,59 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestCommits(t *testing.T) {
+	commits := []*models.Commit{
+		{Id: "1", PrecedingCommits: 1, AuthorName: "Alice", CommitterName: "Alice", CommitterDate: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: "3", PrecedingCommits: 3, AuthorName: "Bob", AuthorEmail: "bob@gentoo.org", CommitterName: "Alice", CommitterDate: time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{Id: "2", PrecedingCommits: 2, AuthorName: "Carol", CommitterName: "Carol", CommitterDate: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	var tests = []struct {
+		name   string
+		filter CommitFilter
+		want   string
+	}{
+		{"no filter", CommitFilter{}, "3 2 1"},
+		{"limit", CommitFilter{Limit: 2}, "3 2"},
+		{"since", CommitFilter{Since: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)}, "3 2"},
+		{"until", CommitFilter{Until: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)}, "2 1"},
+		{"author", CommitFilter{Author: "alice"}, "3 1"},
+		{"author email", CommitFilter{Author: "bob@"}, "3"},
+		{"limit after filter", CommitFilter{Author: "alice", Limit: 1, Until: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)}, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, commit := range Commits(commits, tt.filter) {
+				ids = append(ids, commit.Id)
+			}
+			if ret := strings.Join(ids, " "); ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCommits(t *testing.T) {
+	commits := []*models.Commit{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+	version := &models.Version{Commits: []*models.Commit{{Id: "3"}, {Id: "1"}}}
+
+	var ids []string
+	for _, commit := range VersionCommits(commits, version) {
+		ids = append(ids, commit.Id)
+	}
+	if ret := strings.Join(ids, " "); ret != "1 3" {
+		t.Errorf("got %s, want 1 3", ret)
+	}
+	if ret := VersionCommits(commits, nil); ret != nil {
+		t.Errorf("got %v, want no commits", ret)
+	}
+}
@@ -0,0 +1,18 @@
+package filter
+
+import (
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// ReverseDependencies returns the reverse dependencies that use any
+// of the given dependency types, i.e. rdepend. In case no types are
+// given, all reverse dependencies are returned.
+func ReverseDependencies(deps []*models.ReverseDependency, types []string) []*models.ReverseDependency {
+	var filtered []*models.ReverseDependency
+	for _, dep := range deps {
+		if matchesAny(dep.Type, types) {
+			filtered = append(filtered, dep)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,37 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestReverseDependencies(t *testing.T) {
+	deps := []*models.ReverseDependency{
+		{Id: "1", Type: "rdepend"},
+		{Id: "2", Type: "bdepend"},
+		{Id: "3", Type: "DEPEND"},
+	}
+
+	var tests = []struct {
+		types []string
+		want  string
+	}{
+		{nil, "1 2 3"},
+		{[]string{"rdepend"}, "1"},
+		{[]string{"depend", "bdepend"}, "2 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.types, ","), func(t *testing.T) {
+			var ids []string
+			for _, dep := range ReverseDependencies(deps, tt.types) {
+				ids = append(ids, dep.Id)
+			}
+			if ret := strings.Join(ids, " "); ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Contains filters to narrow down the sections of a package
+
+package filter
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAge parses an age such as '30d', '2w' or any
+// duration that is understood by time.ParseDuration
+func ParseAge(age string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if strings.HasSuffix(age, suffix) {
+			count, err := strconv.Atoi(strings.TrimSuffix(age, suffix))
+			if err != nil || count < 0 {
+				return 0, errors.New("invalid age '" + age + "'")
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil || duration < 0 {
+		return 0, errors.New("invalid age '" + age + "'")
+	}
+	return duration, nil
+}
+
+// ParseDate parses a date such as '2020-06-01', or an age such
+// as '30d' that is counted back from the given point in time
+func ParseDate(date string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, nil
+	}
+	age, err := ParseAge(date)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date '" + date + "', expected i.e. 2020-06-01 or 30d")
+	}
+	return now.Add(-age), nil
+}
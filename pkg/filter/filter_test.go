@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	var tests = []struct {
+		age     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"0d", 0, false},
+		{"d", 0, true},
+		{"-3d", 0, true},
+		{"30 days", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age, func(t *testing.T) {
+			ret, err := ParseAge(tt.age)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		date    string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2020-06-01", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2w", time.Date(2020, 6, 16, 12, 0, 0, 0, time.UTC), false},
+		{"06/01/2020", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			ret, err := ParseDate(tt.date, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if !ret.Equal(tt.want) {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package filter
+
+import (
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// Outdated returns the versions released by upstream that the
+// version in Gentoo is actually behind of
+func Outdated(outdated []*models.OutdatedPackages) []*models.OutdatedPackages {
+	var filtered []*models.OutdatedPackages
+	for _, o := range outdated {
+		if _, behind := o.Lag(); behind {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered
+}
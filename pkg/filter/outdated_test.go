@@ -0,0 +1,25 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestOutdated(t *testing.T) {
+	outdated := []*models.OutdatedPackages{
+		{GentooVersion: "1.14.4", NewestVersion: "1.15"},
+		{GentooVersion: "1.15", NewestVersion: "1.15"},
+		{GentooVersion: "1.16", NewestVersion: "1.15"},
+		{GentooVersion: "1.14.4", NewestVersion: "1.14.5"},
+	}
+
+	var newest []string
+	for _, o := range Outdated(outdated) {
+		newest = append(newest, o.GentooVersion+"<"+o.NewestVersion)
+	}
+	if got, want := strings.Join(newest, " "), "1.14.4<1.15 1.14.4<1.14.5"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
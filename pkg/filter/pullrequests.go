@@ -0,0 +1,56 @@
+package filter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// PullRequestFilter selects pull requests. Empty fields match all pull requests.
+type PullRequestFilter struct {
+	// CiState is the state of the CI, i.e. 'success' or 'failure'
+	CiState string
+	// Labels are the labels a pull request must all have
+	Labels []string
+	// StaleFor is the minimum time since the last update
+	StaleFor time.Duration
+}
+
+// PullRequests returns the pull requests that match the given filter
+func PullRequests(pullRequests []*models.GithubPullRequest, f PullRequestFilter, now time.Time) []*models.GithubPullRequest {
+	var filtered []*models.GithubPullRequest
+	for _, pr := range pullRequests {
+		if f.matches(pr, now) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
+func (f PullRequestFilter) matches(pr *models.GithubPullRequest, now time.Time) bool {
+	if f.CiState != "" && !strings.EqualFold(pr.CiState, f.CiState) {
+		return false
+	}
+	for _, label := range f.Labels {
+		if !hasLabel(pr, label) {
+			return false
+		}
+	}
+	if f.StaleFor > 0 {
+		updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt)
+		if err != nil || now.Sub(updatedAt) < f.StaleFor {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLabel(pr *models.GithubPullRequest, name string) bool {
+	for _, label := range pr.Labels {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
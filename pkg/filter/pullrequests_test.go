@@ -0,0 +1,43 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestPullRequests(t *testing.T) {
+	now := time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)
+	pullRequests := []*models.GithubPullRequest{
+		{Id: "1", CiState: "SUCCESS", UpdatedAt: "2020-06-29T00:00:00Z", Labels: []models.GitHubPullRequestLabelNode{{Name: "assigned"}}},
+		{Id: "2", CiState: "FAILURE", UpdatedAt: "2020-04-01T00:00:00Z", Labels: []models.GitHubPullRequestLabelNode{{Name: "assigned"}, {Name: "bug"}}},
+		{Id: "3", CiState: "FAILURE", UpdatedAt: "2020-06-20T00:00:00Z"},
+	}
+
+	var tests = []struct {
+		name   string
+		filter PullRequestFilter
+		want   string
+	}{
+		{"no filter", PullRequestFilter{}, "1 2 3"},
+		{"ci state", PullRequestFilter{CiState: "failure"}, "2 3"},
+		{"label", PullRequestFilter{Labels: []string{"assigned"}}, "1 2"},
+		{"labels", PullRequestFilter{Labels: []string{"assigned", "bug"}}, "2"},
+		{"stale", PullRequestFilter{StaleFor: 7 * 24 * time.Hour}, "2 3"},
+		{"combined", PullRequestFilter{CiState: "failure", StaleFor: 30 * 24 * time.Hour}, "2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, pr := range PullRequests(pullRequests, tt.filter, now) {
+				ids = append(ids, pr.Id)
+			}
+			if ret := strings.Join(ids, " "); ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Contains the dependency graph of a package and its dot and mermaid encodings
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/arzano/pgo/pkg/view"
+)
+
+// Formats are the supported graph formats
+var Formats = []string{"dot", "mermaid"}
+
+// Edge points from a package to one of its dependencies
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// Graph contains the dependencies around the Root package
+type Graph struct {
+	Root  string
+	Edges []Edge
+}
+
+// Dependencies creates the dependency graph of the given package. The
+// forward dependencies of the given version point from the package to
+// its dependencies, while the reverse dependencies point from the
+// depending packages to the package, labeled with the depending
+// versions. Both directions share one node per package, so circular
+// dependencies show up as cycles. Either of them may be omitted by
+// passing a nil version or no reverse dependencies.
+func Dependencies(atom string, version *models.Version, reverseDependencies []*models.ReverseDependency) Graph {
+	g := Graph{Root: atom}
+	if version != nil {
+		for _, dependency := range view.Dependencies(version.Dependencies) {
+			g.Edges = append(g.Edges, Edge{From: atom, To: dependency.Atom, Label: label(dependency)})
+		}
+	}
+	g.Edges = append(g.Edges, reverseEdges(atom, reverseDependencies)...)
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		if g.Edges[i].To != g.Edges[j].To {
+			return g.Edges[i].To < g.Edges[j].To
+		}
+		return g.Edges[i].Label < g.Edges[j].Label
+	})
+	return g
+}
+
+// reverseEdges returns the edges from the packages depending on the
+// given atom to it. Versions of a package depending on it in the same
+// way share one edge, i.e. '1.0, 1.1: rdepend if go'.
+func reverseEdges(atom string, reverseDependencies []*models.ReverseDependency) []Edge {
+	atoms := map[string]string{}
+	for _, revDep := range reverseDependencies {
+		atoms[revDep.ReverseDependencyVersion] = revDep.ReverseDependencyAtom
+	}
+
+	type key struct{ from, label string }
+	var keys []key
+	versions := map[key][]string{}
+	for _, dependency := range view.ReverseDependencies(reverseDependencies) {
+		from := atoms[dependency.Atom]
+		k := key{from, label(dependency)}
+		if _, ok := versions[k]; !ok {
+			keys = append(keys, k)
+		}
+		versions[k] = append(versions[k], strings.TrimPrefix(dependency.Atom, from+"-"))
+	}
+
+	var edges []Edge
+	for _, k := range keys {
+		edges = append(edges, Edge{From: k.from, To: atom, Label: strings.Join(versions[k], ", ") + ": " + k.label})
+	}
+	return edges
+}
+
+// Nodes returns the sorted names of all nodes of the graph
+func (g Graph) Nodes() []string {
+	seen := map[string]bool{g.Root: true}
+	nodes := []string{g.Root}
+	for _, edge := range g.Edges {
+		for _, node := range []string{edge.From, edge.To} {
+			if !seen[node] {
+				seen[node] = true
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Write writes the graph to w in the given format
+func Write(w io.Writer, format string, g Graph) error {
+	switch format {
+	case "dot":
+		return writeDot(w, g)
+	case "mermaid":
+		return writeMermaid(w, g)
+	}
+	return fmt.Errorf("unknown graph format '%s', expected one of: %s", format, strings.Join(Formats, ", "))
+}
+
+// writeDot writes the graph in the graphviz dot language
+func writeDot(w io.Writer, g Graph) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Root))
+	fmt.Fprintf(&b, "  %s [style=bold];\n", dotQuote(g.Root))
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Label))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMermaid writes the graph as mermaid flowchart
+func writeMermaid(w io.Writer, g Graph) error {
+	ids := map[string]string{}
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for idx, node := range g.Nodes() {
+		ids[node] = fmt.Sprintf("n%d", idx)
+		fmt.Fprintf(&b, "  %s[%s]\n", ids[node], mermaidQuote(node))
+	}
+	fmt.Fprintf(&b, "  style %s stroke-width:3px\n", ids[g.Root])
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[edge.From], mermaidQuote(edge.Label), ids[edge.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// label describes the dependency types and the USE condition of a dependency
+func label(dependency view.Dependency) string {
+	s := strings.Join(dependency.Types, ", ")
+	if dependency.Condition != "" {
+		s += " if " + dependency.Condition
+	}
+	return s
+}
+
+// dotQuote returns s as quoted dot identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mermaidQuote returns s as quoted mermaid text
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
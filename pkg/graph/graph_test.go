@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func testGraph() Graph {
+	version := &models.Version{
+		Version: "1.14.4",
+		Dependencies: []*models.ReverseDependency{
+			{Atom: "sys-devel/gcc", Type: "rdepend", Condition: "gccgo"},
+			{Atom: "dev-lang/go-bootstrap", Type: "depend", Condition: "!gccgo"},
+			{Atom: "sys-devel/gcc", Type: "depend", Condition: "gccgo"},
+		},
+	}
+	revDeps := []*models.ReverseDependency{
+		{ReverseDependencyAtom: "dev-go/a", ReverseDependencyVersion: "dev-go/a-1.0", Type: "bdepend"},
+		{ReverseDependencyAtom: "dev-go/a", ReverseDependencyVersion: "dev-go/a-1.1", Type: "bdepend"},
+		{ReverseDependencyAtom: "app-misc/b", ReverseDependencyVersion: "app-misc/b-2.0", Type: "rdepend", Condition: `"x"`},
+	}
+	return Dependencies("dev-lang/go", version, revDeps)
+}
+
+func TestWrite(t *testing.T) {
+	var tests = []struct {
+		format string
+		want   string
+	}{
+		{"dot", `digraph "dev-lang/go" {
+  "dev-lang/go" [style=bold];
+  "app-misc/b" -> "dev-lang/go" [label="2.0: rdepend if \"x\""];
+  "dev-go/a" -> "dev-lang/go" [label="1.0, 1.1: bdepend"];
+  "dev-lang/go" -> "dev-lang/go-bootstrap" [label="depend if !gccgo"];
+  "dev-lang/go" -> "sys-devel/gcc" [label="depend, rdepend if gccgo"];
+}
+`},
+		{"mermaid", `graph LR
+  n0["app-misc/b"]
+  n1["dev-go/a"]
+  n2["dev-lang/go"]
+  n3["dev-lang/go-bootstrap"]
+  n4["sys-devel/gcc"]
+  style n2 stroke-width:3px
+  n0 -->|"2.0: rdepend if #quot;x#quot;"| n2
+  n1 -->|"1.0, 1.1: bdepend"| n2
+  n2 -->|"depend if !gccgo"| n3
+  n2 -->|"depend, rdepend if gccgo"| n4
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, testGraph()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "svg", testGraph()); err == nil {
+		t.Errorf("got no error for unknown format")
+	}
+}
+
+func TestDependencies_Direction(t *testing.T) {
+	g := testGraph()
+	forward := Dependencies(g.Root, &models.Version{Dependencies: []*models.ReverseDependency{{Atom: "sys-devel/gcc", Type: "depend"}}}, nil)
+	if len(forward.Edges) != 1 || forward.Edges[0].From != "dev-lang/go" {
+		t.Errorf("got %v, want a single edge from dev-lang/go", forward.Edges)
+	}
+	reverse := Dependencies(g.Root, nil, []*models.ReverseDependency{{ReverseDependencyAtom: "dev-go/a", ReverseDependencyVersion: "dev-go/a-1.0", Type: "depend"}})
+	if len(reverse.Edges) != 1 || reverse.Edges[0].From != "dev-go/a" || reverse.Edges[0].To != "dev-lang/go" {
+		t.Errorf("got %v, want a single edge from dev-go/a to dev-lang/go", reverse.Edges)
+	}
+}
+
+func TestDependencies_Cycle(t *testing.T) {
+	version := &models.Version{Dependencies: []*models.ReverseDependency{{Atom: "dev-go/b", Type: "rdepend"}}}
+	revDeps := []*models.ReverseDependency{{ReverseDependencyAtom: "dev-go/b", ReverseDependencyVersion: "dev-go/b-2.0", Type: "depend"}}
+	g := Dependencies("dev-go/a", version, revDeps)
+
+	if nodes := g.Nodes(); len(nodes) != 2 || nodes[0] != "dev-go/a" || nodes[1] != "dev-go/b" {
+		t.Errorf("got nodes %v, want [dev-go/a dev-go/b]", nodes)
+	}
+	want := []Edge{
+		{From: "dev-go/a", To: "dev-go/b", Label: "rdepend"},
+		{From: "dev-go/b", To: "dev-go/a", Label: "2.0: depend"},
+	}
+	if len(g.Edges) != len(want) {
+		t.Fatalf("got edges %v, want %v", g.Edges, want)
+	}
+	for idx := range want {
+		if g.Edges[idx] != want[idx] {
+			t.Errorf("got edge %v, want %v", g.Edges[idx], want[idx])
+		}
+	}
+}
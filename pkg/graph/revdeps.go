@@ -0,0 +1,133 @@
+// Contains the traversal of the reverse dependencies of packages
+
+package graph
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/arzano/pgo/pkg/stringslice"
+)
+
+// Fetcher returns the atoms of the packages depending on the given atom
+type Fetcher func(ctx context.Context, atom string) ([]string, error)
+
+// Node is a package in a reverse dependency tree
+type Node struct {
+	Atom     string  `json:"atom"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+// ReverseDependencies follows the reverse dependencies of the given atom
+// up to the given depth. The packages of each level are looked up
+// concurrently using at most workers lookups at a time. Each package is
+// only looked up once, so that packages that are reached again are
+// included in the tree, but are not expanded again.
+func ReverseDependencies(ctx context.Context, atom string, depth, workers int, fetch Fetcher) (*Node, error) {
+	root := &Node{Atom: atom}
+	visited := map[string]bool{atom: true}
+
+	level := []*Node{root}
+	for d := 0; d < depth && len(level) > 0; d++ {
+		atoms := make([]string, len(level))
+		for idx, node := range level {
+			atoms[idx] = node.Atom
+		}
+		results, err := fetchAll(ctx, atoms, workers, fetch)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []*Node
+		for idx, node := range level {
+			for _, revDep := range stringslice.Unique(results[idx]) {
+				if revDep == node.Atom {
+					continue
+				}
+				child := &Node{Atom: revDep}
+				node.Children = append(node.Children, child)
+				if !visited[revDep] {
+					visited[revDep] = true
+					next = append(next, child)
+				}
+			}
+		}
+		level = next
+	}
+	return root, nil
+}
+
+// Atoms returns the deduplicated and sorted atoms of all
+// packages in the tree, except for the root itself
+func (n *Node) Atoms() []string {
+	seen := map[string]bool{n.Atom: true}
+	var atoms []string
+	var walk func(node *Node)
+	walk = func(node *Node) {
+		for _, child := range node.Children {
+			if !seen[child.Atom] {
+				seen[child.Atom] = true
+				atoms = append(atoms, child.Atom)
+			}
+			walk(child)
+		}
+	}
+	walk(n)
+	sort.Strings(atoms)
+	return atoms
+}
+
+// fetchAll looks up the reverse dependencies of all given atoms using
+// at most workers concurrent lookups. The results have the same order
+// as the atoms. The first error cancels the remaining lookups.
+func fetchAll(ctx context.Context, atoms []string, workers int, fetch Fetcher) ([][]string, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]string, len(atoms))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for w := 0; w < workers && w < len(atoms); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				revDeps, err := fetch(ctx, atoms[idx])
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[idx] = revDeps
+			}
+		}()
+	}
+
+loop:
+	for idx := range atoms {
+		select {
+		case jobs <- idx:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testRevDeps = map[string][]string{
+	"dev-lang/go": {"dev-go/b", "dev-go/a", "dev-go/a"},
+	"dev-go/a":    {"app-misc/c", "dev-lang/go"},
+	"dev-go/b":    {"app-misc/c", "dev-go/b"},
+	"app-misc/c":  {"app-misc/d"},
+}
+
+func testFetcher(ctx context.Context, atom string) ([]string, error) {
+	return testRevDeps[atom], nil
+}
+
+// format returns the tree as indented lines
+func format(node *Node, indent string) string {
+	s := indent + node.Atom + "\n"
+	for _, child := range node.Children {
+		s += format(child, indent+"  ")
+	}
+	return s
+}
+
+func TestReverseDependencies(t *testing.T) {
+	var tests = []struct {
+		depth int
+		tree  string
+		atoms string
+	}{
+		{0, "dev-lang/go\n", ""},
+		{1, "dev-lang/go\n  dev-go/a\n  dev-go/b\n", "dev-go/a dev-go/b"},
+		{2, "dev-lang/go\n  dev-go/a\n    app-misc/c\n    dev-lang/go\n  dev-go/b\n    app-misc/c\n", "app-misc/c dev-go/a dev-go/b"},
+		{5, "dev-lang/go\n  dev-go/a\n    app-misc/c\n      app-misc/d\n    dev-lang/go\n  dev-go/b\n    app-misc/c\n", "app-misc/c app-misc/d dev-go/a dev-go/b"},
+	}
+
+	for _, tt := range tests {
+		root, err := ReverseDependencies(context.Background(), "dev-lang/go", tt.depth, 2, testFetcher)
+		if err != nil {
+			t.Fatalf("depth %d: unexpected error: %v", tt.depth, err)
+		}
+		if got := format(root, ""); got != tt.tree {
+			t.Errorf("depth %d: got\n%s, want\n%s", tt.depth, got, tt.tree)
+		}
+		if got := strings.Join(root.Atoms(), " "); got != tt.atoms {
+			t.Errorf("depth %d: got %s, want %s", tt.depth, got, tt.atoms)
+		}
+	}
+}
+
+func TestReverseDependencies_Workers(t *testing.T) {
+	revDeps := map[string][]string{}
+	for _, atom := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		revDeps["root"] = append(revDeps["root"], "cat/"+atom)
+	}
+
+	var mu sync.Mutex
+	var running, maxRunning, lookups int
+	fetch := func(ctx context.Context, atom string) ([]string, error) {
+		mu.Lock()
+		running++
+		lookups++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return revDeps[atom], nil
+	}
+
+	if _, err := ReverseDependencies(context.Background(), "root", 2, 3, fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxRunning > 3 {
+		t.Errorf("got %d concurrent lookups, want at most 3", maxRunning)
+	}
+	if lookups != 9 {
+		t.Errorf("got %d lookups, want 9", lookups)
+	}
+}
+
+func TestReverseDependencies_Error(t *testing.T) {
+	errFailed := errors.New("failed")
+	fetch := func(ctx context.Context, atom string) ([]string, error) {
+		if atom == "app-misc/c" {
+			return nil, errFailed
+		}
+		return testRevDeps[atom], nil
+	}
+
+	if _, err := ReverseDependencies(context.Background(), "dev-lang/go", 5, 2, fetch); !errors.Is(err, errFailed) {
+		t.Errorf("got %v, want %v", err, errFailed)
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
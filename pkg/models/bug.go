@@ -14,3 +14,8 @@ type PackageToBug struct {
 	PackageAtom string
 	BugId       string
 }
+
+// IsSecurity returns true if the bug is handled by the security team
+func (b Bug) IsSecurity() bool {
+	return b.Product == "Gentoo Security" || b.Component == "Vulnerabilities"
+}
@@ -0,0 +1,102 @@
+// Contains the model of the keywords of a version
+
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// KeywordState is the state of a version on an arch
+type KeywordState int
+
+const (
+	// KeywordUnknown means the version is not keyworded on the arch
+	KeywordUnknown KeywordState = iota
+	// KeywordStable means the version is stable on the arch, i.e. 'amd64'
+	KeywordStable
+	// KeywordTesting means the version is in testing on the arch, i.e. '~amd64'
+	KeywordTesting
+	// KeywordDisabled means the version is known to be broken on the arch, i.e. '-amd64'
+	KeywordDisabled
+)
+
+func (s KeywordState) String() string {
+	switch s {
+	case KeywordStable:
+		return "stable"
+	case KeywordTesting:
+		return "testing"
+	case KeywordDisabled:
+		return "disabled"
+	}
+	return "unknown"
+}
+
+// wildcard is the key of the state of all arches that are not listed explicitly
+const wildcard = "*"
+
+// Keywords maps arches to the state of a version on them
+type Keywords map[string]KeywordState
+
+// ParseKeywords parses the given keywords, i.e.
+//   amd64 ~arm64 -sparc -*
+// The wildcards '-*', '~*', '**' and '*' set the state of all
+// arches that are not listed explicitly to disabled, testing,
+// testing and stable respectively.
+func ParseKeywords(keywords string) Keywords {
+	parsed := Keywords{}
+	for _, keyword := range strings.Fields(keywords) {
+		switch {
+		case keyword == "**":
+			parsed[wildcard] = KeywordTesting
+		case strings.HasPrefix(keyword, "~"):
+			parsed[keyword[1:]] = KeywordTesting
+		case strings.HasPrefix(keyword, "-"):
+			parsed[keyword[1:]] = KeywordDisabled
+		default:
+			parsed[keyword] = KeywordStable
+		}
+	}
+	return parsed
+}
+
+// State returns the state of the version on the given arch
+func (k Keywords) State(arch string) KeywordState {
+	if state, ok := k[arch]; ok {
+		return state
+	}
+	return k[wildcard]
+}
+
+// StableOn returns true if the version is stable on the given arch
+func (k Keywords) StableOn(arch string) bool {
+	return k.State(arch) == KeywordStable
+}
+
+// TestingOn returns true if the version is in testing on the given arch
+func (k Keywords) TestingOn(arch string) bool {
+	return k.State(arch) == KeywordTesting
+}
+
+// DisabledOn returns true if the version is known to be broken on the given arch
+func (k Keywords) DisabledOn(arch string) bool {
+	return k.State(arch) == KeywordDisabled
+}
+
+// Arches returns the sorted arches that are listed explicitly
+func (k Keywords) Arches() []string {
+	var arches []string
+	for arch := range k {
+		if arch != wildcard {
+			arches = append(arches, arch)
+		}
+	}
+	sort.Strings(arches)
+	return arches
+}
+
+// ParseKeywords returns the parsed keywords of the version
+func (v Version) ParseKeywords() Keywords {
+	return ParseKeywords(v.Keywords)
+}
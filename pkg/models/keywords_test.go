@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestKeywords_State(t *testing.T) {
+	var tests = []struct {
+		keywords, arch string
+		want           KeywordState
+	}{
+		{"amd64 ~arm64", "amd64", KeywordStable},
+		{"amd64 ~arm64", "arm64", KeywordTesting},
+		{"amd64 ~arm64", "riscv", KeywordUnknown},
+		{"amd64 -sparc", "sparc", KeywordDisabled},
+		{"", "amd64", KeywordUnknown},
+
+		// wildcards only apply to arches that are not listed explicitly
+		{"~amd64 -*", "amd64", KeywordTesting},
+		{"~amd64 -*", "arm64", KeywordDisabled},
+		{"**", "m68k", KeywordTesting},
+		{"~*", "m68k", KeywordTesting},
+		{"*", "m68k", KeywordStable},
+		{"-* amd64", "amd64", KeywordStable},
+	}
+
+	for _, tt := range tests {
+		testname := fmt.Sprintf("%s(%s)", tt.keywords, tt.arch)
+		t.Run(testname, func(t *testing.T) {
+			ret := ParseKeywords(tt.keywords).State(tt.arch)
+			if ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeywords_Helpers(t *testing.T) {
+	keywords := Version{Keywords: "amd64 ~arm64 -sparc"}.ParseKeywords()
+
+	if !keywords.StableOn("amd64") || keywords.StableOn("arm64") {
+		t.Errorf("StableOn: got %t, %t, want true, false", keywords.StableOn("amd64"), keywords.StableOn("arm64"))
+	}
+	if !keywords.TestingOn("arm64") || keywords.TestingOn("amd64") {
+		t.Errorf("TestingOn: got %t, %t, want true, false", keywords.TestingOn("arm64"), keywords.TestingOn("amd64"))
+	}
+	if !keywords.DisabledOn("sparc") || keywords.DisabledOn("x86") {
+		t.Errorf("DisabledOn: got %t, %t, want true, false", keywords.DisabledOn("sparc"), keywords.DisabledOn("x86"))
+	}
+}
+
+func TestKeywords_Arches(t *testing.T) {
+	var tests = []struct {
+		keywords string
+		want     string
+	}{
+		{"~riscv amd64 -sparc ~arm64", "amd64 arm64 riscv sparc"},
+		{"-* ~amd64", "amd64"},
+		{"**", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keywords, func(t *testing.T) {
+			ret := strings.Join(ParseKeywords(tt.keywords).Arches(), " ")
+			if ret != tt.want {
+				t.Errorf("got %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
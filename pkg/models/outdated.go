@@ -2,8 +2,83 @@
 
 package models
 
+import (
+	"strconv"
+	"strings"
+)
+
 type OutdatedPackages struct {
 	Atom          string `pg:",pk"`
 	GentooVersion string
 	NewestVersion string
 }
+
+// lagComponents are the names of the first numeric components of a version
+var lagComponents = []string{"major", "minor", "patch"}
+
+// Lag describes how far a version is behind a newer version,
+// i.e. 1.14.4 is one minor version behind 1.15
+type Lag struct {
+	// Component is the index of the first numeric component
+	// that differs, i.e. 0 for the major version
+	Component int
+	// Amount is the difference of that component
+	Amount int
+}
+
+// Lag returns how far the Gentoo version is behind the newest version.
+// False is returned if the Gentoo version is not behind.
+func (o OutdatedPackages) Lag() (Lag, bool) {
+	if o.GentooVersion == "" || o.NewestVersion == "" {
+		return Lag{}, false
+	}
+	gentoo := &Version{Version: o.GentooVersion}
+	newest := &Version{Version: o.NewestVersion}
+	if !newest.GreaterThan(*gentoo) {
+		return Lag{}, false
+	}
+
+	gentooParts := strings.Split(gentoo.computeVersionIdentifier().NumericPart, ".")
+	newestParts := strings.Split(newest.computeVersionIdentifier().NumericPart, ".")
+	for i, newestPart := range newestParts {
+		gentooPart := "0"
+		if i < len(gentooParts) {
+			gentooPart = gentooParts[i]
+		}
+		if numberGreaterThan(newestPart, gentooPart) {
+			amount := 1
+			a, errA := strconv.Atoi(newestPart)
+			b, errB := strconv.Atoi(gentooPart)
+			if errA == nil && errB == nil && a-b > 1 {
+				amount = a - b
+			}
+			return Lag{Component: i, Amount: amount}, true
+		} else if numberGreaterThan(gentooPart, newestPart) {
+			break
+		}
+	}
+	// the versions only differ in their letters, suffixes or revisions,
+	// which is less significant than any of the named components
+	component := len(newestParts)
+	if component < len(lagComponents) {
+		component = len(lagComponents)
+	}
+	return Lag{Component: component, Amount: 1}, true
+}
+
+// GreaterThan returns true if the lag is bigger than the given lag,
+// that is if a more significant component differs or by a larger amount
+func (l Lag) GreaterThan(other Lag) bool {
+	if l.Component != other.Component {
+		return l.Component < other.Component
+	}
+	return l.Amount > other.Amount
+}
+
+// String describes the lag, i.e. '2 major' or '1 patch'
+func (l Lag) String() string {
+	if l.Component < len(lagComponents) {
+		return strconv.Itoa(l.Amount) + " " + lagComponents[l.Component]
+	}
+	return "minor changes"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestOutdatedPackages_Lag(t *testing.T) {
+	var tests = []struct {
+		gentoo, newest string
+		want           Lag
+		behind         bool
+	}{
+		{"1.0", "3.0", Lag{0, 2}, true},
+		{"1.14.4", "1.15", Lag{1, 1}, true},
+		{"1.14.4", "1.14.10", Lag{2, 6}, true},
+		{"1.14", "1.14.1", Lag{2, 1}, true},
+		{"1.0", "1.0b", Lag{3, 1}, true},
+		{"1.0_rc1", "1.0", Lag{3, 1}, true},
+		{"1.2.3.4", "1.2.3.5", Lag{3, 1}, true},
+		{"1.15", "1.15", Lag{}, false},
+		{"1.16", "1.15", Lag{}, false},
+		{"", "1.15", Lag{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gentoo+" "+tt.newest, func(t *testing.T) {
+			lag, behind := OutdatedPackages{GentooVersion: tt.gentoo, NewestVersion: tt.newest}.Lag()
+			if lag != tt.want || behind != tt.behind {
+				t.Errorf("got %v %t, want %v %t", lag, behind, tt.want, tt.behind)
+			}
+		})
+	}
+}
+
+func TestLag_GreaterThan(t *testing.T) {
+	var tests = []struct {
+		left, right Lag
+		want        bool
+	}{
+		{Lag{0, 1}, Lag{1, 5}, true},
+		{Lag{1, 5}, Lag{0, 1}, false},
+		{Lag{1, 5}, Lag{1, 2}, true},
+		{Lag{1, 2}, Lag{1, 2}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.left.GreaterThan(tt.right); got != tt.want {
+			t.Errorf("%v > %v: got %t, want %t", tt.left, tt.right, got, tt.want)
+		}
+	}
+}
@@ -133,6 +133,34 @@ func (v *Version) EqualTo(other Version) bool {
 	return !v.GreaterThan(other) && !v.SmallerThan(other)
 }
 
+// IsLive returns true if the version is a live version, which is either
+// declared using PROPERTIES=live, or detected by its last numeric
+// component consisting of at least four nines, i.e. 9999 or 1.2.9999
+func (v *Version) IsLive() bool {
+	for _, property := range v.Properties {
+		if property == "live" {
+			return true
+		}
+	}
+	numericParts := strings.Split(v.computeVersionIdentifier().NumericPart, ".")
+	last := numericParts[len(numericParts)-1]
+	return len(last) >= 4 && strings.Trim(last, "9") == ""
+}
+
+// NewestRelease returns the newest of the given versions that is not a
+// live version. If there are only live versions, the newest of them is
+// returned, and nil if there are no versions at all.
+func NewestRelease(versions []*Version) *Version {
+	var newest *Version
+	for _, version := range versions {
+		if newest == nil || newest.IsLive() && !version.IsLive() ||
+			newest.IsLive() == version.IsLive() && version.GreaterThan(*newest) {
+			newest = version
+		}
+	}
+	return newest
+}
+
 // utils
 
 type VersionIdentifier struct {
@@ -184,3 +184,54 @@ func TestVersion_EqualTo(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_IsLive(t *testing.T) {
+	var tests = []struct {
+		version Version
+		want    bool
+	}{
+		{Version{Version: "9999"}, true},
+		{Version{Version: "1.2.9999"}, true},
+		{Version{Version: "99999999"}, true},
+		{Version{Version: "9999-r1"}, true},
+		{Version{Version: "2.0", Properties: []string{"live"}}, true},
+		{Version{Version: "1.14.4"}, false},
+		{Version{Version: "1.9"}, false},
+		{Version{Version: "999"}, false},
+		{Version{Version: "19999"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version.Version, func(t *testing.T) {
+			if got := tt.version.IsLive(); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewestRelease(t *testing.T) {
+	var tests = []struct {
+		versions []string
+		want     string
+	}{
+		{[]string{"1.13.12", "9999", "1.14.4"}, "1.14.4"},
+		{[]string{"1.2.9999", "1.2.1", "99999999"}, "1.2.1"},
+		{[]string{"9999"}, "9999"},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		var versions []*Version
+		for _, version := range tt.versions {
+			versions = append(versions, &Version{Version: version})
+		}
+		got := ""
+		if newest := NewestRelease(versions); newest != nil {
+			got = newest.Version
+		}
+		if got != tt.want {
+			t.Errorf("NewestRelease(%v): got %q, want %q", tt.versions, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Contains helpers to work with slices of strings
+
+package stringslice
+
+import "sort"
+
+// Contains returns true if the given item is part of the items
+func Contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Unique returns the sorted items without duplicates. The given
+// items are left unchanged.
+func Unique(items []string) []string {
+	sorted := append([]string{}, items...)
+	sort.Strings(sorted)
+	unique := []string{}
+	for i, item := range sorted {
+		if i == 0 || sorted[i-1] != item {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,46 @@
+package stringslice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContains(t *testing.T) {
+	var tests = []struct {
+		items []string
+		item  string
+		want  bool
+	}{
+		{[]string{"dot", "mermaid"}, "dot", true},
+		{[]string{"dot", "mermaid"}, "svg", false},
+		{nil, "dot", false},
+	}
+
+	for _, tt := range tests {
+		if got := Contains(tt.items, tt.item); got != tt.want {
+			t.Errorf("Contains(%v, %s): got %v, want %v", tt.items, tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	var tests = []struct {
+		items []string
+		want  []string
+	}{
+		{[]string{"dev-go/b", "dev-go/a", "dev-go/b"}, []string{"dev-go/a", "dev-go/b"}},
+		{[]string{"dev-go/a"}, []string{"dev-go/a"}},
+		{nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		items := append([]string{}, tt.items...)
+		got := Unique(items)
+		if got == nil || strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("Unique(%v): got %v, want %v", tt.items, got, tt.want)
+		}
+		if strings.Join(items, " ") != strings.Join(tt.items, " ") {
+			t.Errorf("Unique(%v) changed the given items to %v", tt.items, items)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package terminal
+
+// Size always reports that the given file descriptor doesn't
+// refer to a terminal, as detecting it is not supported here.
+func Size(fd uintptr) (int, int, bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,18 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package terminal
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// Size returns the width and height of the terminal the given file descriptor
+// refers to. The last return value is false if it doesn't refer to a terminal.
+func Size(fd uintptr) (int, int, bool) {
+	winsize, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, false
+	}
+	return int(winsize.Col), int(winsize.Row), true
+}
@@ -0,0 +1,39 @@
+// Contains helpers to render text on a terminal
+
+package terminal
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Wrap breaks the given text into lines that are at most width
+// characters long. Words that are longer than width are not split.
+func Wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line == "" {
+			line = word
+		} else if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width {
+			line += " " + word
+		} else {
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Center pads the given text with spaces on both sides so that it is
+// centered in the given width. Text that is too long is not padded.
+func Center(text string, width int) string {
+	padding := width - utf8.RuneCountInString(text)
+	if padding <= 0 {
+		return text
+	}
+	return strings.Repeat(" ", padding/2) + text + strings.Repeat(" ", padding-padding/2)
+}
@@ -0,0 +1,45 @@
+package terminal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	var tests = []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"", 10, nil},
+		{"short", 10, []string{"short"}},
+		{"a concurrent garbage collected language", 15, []string{"a concurrent", "garbage", "collected", "language"}},
+		{"supercalifragilistic word", 5, []string{"supercalifragilistic", "word"}},
+		{"  multiple   spaces  ", 40, []string{"multiple spaces"}},
+	}
+
+	for _, tt := range tests {
+		if got := Wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Wrap(%q, %d): got %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestCenter(t *testing.T) {
+	var tests = []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"go", 6, "  go  "},
+		{"go", 7, "  go   "},
+		{"dev-lang/go", 5, "dev-lang/go"},
+		{"", 2, "  "},
+	}
+
+	for _, tt := range tests {
+		if got := Center(tt.text, tt.width); got != tt.want {
+			t.Errorf("Center(%q, %d): got %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
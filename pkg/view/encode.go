@@ -0,0 +1,45 @@
+// Contains the encoders of the machine-readable output formats
+
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Formats contains the supported machine-readable output formats. All
+// formats render the same view. As TOML can't express an empty array of
+// tables, empty sections of tables are omitted in the toml format, but
+// still listed in the sections of the Package. The
+// markdown and html formats are self-contained reports of a Package or Report.
+var Formats = []string{"json", "yaml", "toml", "markdown", "html"}
+
+// Encode writes v to w using the given format
+func Encode(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case "yaml":
+		encoder := yaml.NewEncoder(w)
+		defer encoder.Close()
+		return encoder.Encode(v)
+	case "toml":
+		return toml.NewEncoder(w).Encode(v)
+	case "markdown", "html":
+		switch data := v.(type) {
+		case Report:
+			return writeReport(w, format, data)
+		case Package:
+			return writeReport(w, format, Report{Package: data, Arches: DefaultArches})
+		}
+		return fmt.Errorf("the %s format only supports packages", format)
+	}
+	return fmt.Errorf("unknown output format '%s'", format)
+}
@@ -0,0 +1,456 @@
+// Contains the machine-readable view of a package
+
+package view
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/arzano/pgo/pkg/stringslice"
+)
+
+// Package is the machine-readable representation of a package.
+// The top-level shape is
+//
+//	{
+//	  "atom": "dev-lang/go",
+//	  "category": "dev-lang",
+//	  "name": "go",
+//	  "description": "A concurrent garbage collected and typesafe programming language",
+//	  "homepage": ["https://golang.org"],
+//	  "sections": ["versions", "masks", ...],
+//	  "versions": [...],
+//	  "masks": [...],
+//	  "metadata": {...},
+//	  "bugs": [...],
+//	  "pullRequests": [...],
+//	  "qaReports": [...],
+//	  "reverseDependencies": [...],
+//	  "reverseDependencyVersions": [...],
+//	  "changelog": [...],
+//	  "upstream": [...]
+//	}
+//
+// The header fields are always present. Each section is only
+// present if it has been requested, in which case it is present
+// even if it is empty. The exception are empty arrays of tables in
+// toml, which can't be expressed, so the requested sections are
+// listed in sections as well.
+type Package struct {
+	Atom                string         `json:"atom" yaml:"atom" toml:"atom"`
+	Category            string         `json:"category" yaml:"category" toml:"category"`
+	Name                string         `json:"name" yaml:"name" toml:"name"`
+	Description         string         `json:"description" yaml:"description" toml:"description"`
+	Homepage            []string       `json:"homepage" yaml:"homepage" toml:"homepage"`
+	Sections            []string       `json:"sections" yaml:"sections" toml:"sections"`
+	Versions            *[]Version     `json:"versions,omitempty" yaml:"versions,omitempty" toml:"versions,omitempty"`
+	Masks               *[]Mask        `json:"masks,omitempty" yaml:"masks,omitempty" toml:"masks,omitempty"`
+	Metadata            *Metadata      `json:"metadata,omitempty" yaml:"metadata,omitempty" toml:"metadata,omitempty"`
+	Bugs                *[]Bug         `json:"bugs,omitempty" yaml:"bugs,omitempty" toml:"bugs,omitempty"`
+	PullRequests        *[]PullRequest `json:"pullRequests,omitempty" yaml:"pullRequests,omitempty" toml:"pullRequests,omitempty"`
+	QAReports           *[]QAReport    `json:"qaReports,omitempty" yaml:"qaReports,omitempty" toml:"qaReports,omitempty"`
+	ReverseDependencies *[]string      `json:"reverseDependencies,omitempty" yaml:"reverseDependencies,omitempty" toml:"reverseDependencies,omitempty"`
+	// ReverseDependencyVersions are the versions depending on the package
+	ReverseDependencyVersions *[]Dependency `json:"reverseDependencyVersions,omitempty" yaml:"reverseDependencyVersions,omitempty" toml:"reverseDependencyVersions,omitempty"`
+	Changelog                 *[]Commit     `json:"changelog,omitempty" yaml:"changelog,omitempty" toml:"changelog,omitempty"`
+	Upstream                  *[]Upstream   `json:"upstream,omitempty" yaml:"upstream,omitempty" toml:"upstream,omitempty"`
+}
+
+// Version is a version of a package, sorted newest first
+type Version struct {
+	Version    string   `json:"version" yaml:"version" toml:"version"`
+	Slot       string   `json:"slot" yaml:"slot" toml:"slot"`
+	Subslot    string   `json:"subslot" yaml:"subslot" toml:"subslot"`
+	EAPI       string   `json:"eapi" yaml:"eapi" toml:"eapi"`
+	Keywords   []string `json:"keywords" yaml:"keywords" toml:"keywords"`
+	Restricts  []string `json:"restricts" yaml:"restricts" toml:"restricts"`
+	Properties []string `json:"properties" yaml:"properties" toml:"properties"`
+	Masked     bool     `json:"masked" yaml:"masked" toml:"masked"`
+
+	// Dependencies are only present if the forward dependencies have been requested
+	Dependencies *[]Dependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty" toml:"dependencies,omitempty"`
+}
+
+// Dependency is an atom a version depends on under the given
+// USE condition, using the given dependency types, i.e. rdepend
+type Dependency struct {
+	Atom      string   `json:"atom" yaml:"atom" toml:"atom"`
+	Types     []string `json:"types" yaml:"types" toml:"types"`
+	Condition string   `json:"condition" yaml:"condition" toml:"condition"`
+}
+
+// Mask is a package.mask entry that covers versions of the package.
+// The masks are part of the versions section.
+type Mask struct {
+	Versions    string    `json:"versions" yaml:"versions" toml:"versions"`
+	Author      string    `json:"author" yaml:"author" toml:"author"`
+	AuthorEmail string    `json:"authorEmail" yaml:"authorEmail" toml:"authorEmail"`
+	Date        time.Time `json:"date" yaml:"date" toml:"date"`
+	Reason      string    `json:"reason" yaml:"reason" toml:"reason"`
+	Covers      []string  `json:"covers" yaml:"covers" toml:"covers"`
+}
+
+type Metadata struct {
+	Longdescription string       `json:"longdescription" yaml:"longdescription" toml:"longdescription"`
+	Useflags        []Useflag    `json:"useflags" yaml:"useflags" toml:"useflags"`
+	License         string       `json:"license" yaml:"license" toml:"license"`
+	Maintainers     []Maintainer `json:"maintainers" yaml:"maintainers" toml:"maintainers"`
+}
+
+type Maintainer struct {
+	Name  string `json:"name" yaml:"name" toml:"name"`
+	Email string `json:"email" yaml:"email" toml:"email"`
+}
+
+type Bug struct {
+	Id        string `json:"id" yaml:"id" toml:"id"`
+	Summary   string `json:"summary" yaml:"summary" toml:"summary"`
+	Product   string `json:"product" yaml:"product" toml:"product"`
+	Component string `json:"component" yaml:"component" toml:"component"`
+	Assignee  string `json:"assignee" yaml:"assignee" toml:"assignee"`
+	Status    string `json:"status" yaml:"status" toml:"status"`
+	Security  bool   `json:"security" yaml:"security" toml:"security"`
+}
+
+type PullRequest struct {
+	Id          string            `json:"id" yaml:"id" toml:"id"`
+	Title       string            `json:"title" yaml:"title" toml:"title"`
+	Author      string            `json:"author" yaml:"author" toml:"author"`
+	Url         string            `json:"url" yaml:"url" toml:"url"`
+	CiState     string            `json:"ciState" yaml:"ciState" toml:"ciState"`
+	CiStateLink string            `json:"ciStateLink" yaml:"ciStateLink" toml:"ciStateLink"`
+	Labels      []string          `json:"labels" yaml:"labels" toml:"labels"`
+	Comments    int               `json:"comments" yaml:"comments" toml:"comments"`
+	Files       []PullRequestFile `json:"files" yaml:"files" toml:"files"`
+	CreatedAt   string            `json:"createdAt" yaml:"createdAt" toml:"createdAt"`
+	UpdatedAt   string            `json:"updatedAt" yaml:"updatedAt" toml:"updatedAt"`
+}
+
+type PullRequestFile struct {
+	Path      string `json:"path" yaml:"path" toml:"path"`
+	Additions int    `json:"additions" yaml:"additions" toml:"additions"`
+	Deletions int    `json:"deletions" yaml:"deletions" toml:"deletions"`
+}
+
+// QAReport is a pkgcheck result. The version is
+// empty if the result applies to all versions.
+type QAReport struct {
+	Version string `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+	Class   string `json:"class" yaml:"class" toml:"class"`
+	Message string `json:"message" yaml:"message" toml:"message"`
+}
+
+// Commit is an entry of the changelog, sorted newest first
+type Commit struct {
+	Id      string    `json:"id" yaml:"id" toml:"id"`
+	Date    time.Time `json:"date" yaml:"date" toml:"date"`
+	Author  string    `json:"author" yaml:"author" toml:"author"`
+	Message string    `json:"message" yaml:"message" toml:"message"`
+}
+
+// Upstream is a newer version that has been released by upstream
+type Upstream struct {
+	GentooVersion string `json:"gentooVersion" yaml:"gentooVersion" toml:"gentooVersion"`
+	NewestVersion string `json:"newestVersion" yaml:"newestVersion" toml:"newestVersion"`
+}
+
+// NewPackage creates the view of the given package that only contains
+// the given sections. The USE flags of the metadata are described
+// using the given USE flag records, which may be nil.
+func NewPackage(gpackage models.Package, sections client.Section, useflags []models.Useflag) Package {
+	p := Package{
+		Atom:        gpackage.Atom,
+		Category:    gpackage.Category,
+		Name:        gpackage.Name,
+		Description: gpackage.Description(),
+		Homepage:    []string{},
+	}
+	if len(gpackage.Versions) > 0 && gpackage.Versions[0].Homepage != nil {
+		p.Homepage = gpackage.Versions[0].Homepage
+	}
+
+	if sections&(client.SectionVersions|client.SectionForwardDependencies) != 0 {
+		versions := newVersions(gpackage.Versions, sections&client.SectionForwardDependencies != 0)
+		p.Versions = &versions
+	}
+	if sections&client.SectionVersions != 0 {
+		masks := CollectMasks(gpackage.Versions)
+		p.Masks = &masks
+	}
+	if sections&client.SectionMetadata != 0 {
+		p.Metadata = newMetadata(gpackage, useflags)
+	}
+	if sections&client.SectionBugs != 0 {
+		bugs := []Bug{}
+		for _, bug := range gpackage.Bugs {
+			bugs = append(bugs, Bug{
+				Id:        bug.Id,
+				Summary:   bug.Summary,
+				Product:   bug.Product,
+				Component: bug.Component,
+				Assignee:  bug.Assignee,
+				Status:    bug.Status,
+				Security:  bug.IsSecurity(),
+			})
+		}
+		p.Bugs = &bugs
+	}
+	if sections&client.SectionPullRequests != 0 {
+		pullRequests := []PullRequest{}
+		for _, pr := range gpackage.PullRequests {
+			pullRequests = append(pullRequests, newPullRequest(pr))
+		}
+		p.PullRequests = &pullRequests
+	}
+	if sections&client.SectionQAReports != 0 {
+		qaReports := newQAReports(gpackage)
+		p.QAReports = &qaReports
+	}
+	if sections&client.SectionDependencies != 0 {
+		revDeps := []string{}
+		for _, revDep := range gpackage.ReverseDependencies {
+			revDeps = append(revDeps, revDep.ReverseDependencyAtom)
+		}
+		revDeps = stringslice.Unique(revDeps)
+		p.ReverseDependencies = &revDeps
+		revDepVersions := ReverseDependencies(gpackage.ReverseDependencies)
+		p.ReverseDependencyVersions = &revDepVersions
+	}
+	if sections&client.SectionChangelog != 0 {
+		changelog := newChangelog(gpackage.Commits)
+		p.Changelog = &changelog
+	}
+	if sections&client.SectionUpstream != 0 {
+		upstream := []Upstream{}
+		for _, outdated := range gpackage.Outdated {
+			upstream = append(upstream, Upstream{GentooVersion: outdated.GentooVersion, NewestVersion: outdated.NewestVersion})
+		}
+		p.Upstream = &upstream
+	}
+	p.Sections = requestedSections(p)
+	return p
+}
+
+// requestedSections returns the keys of the sections present in the package
+func requestedSections(p Package) []string {
+	present := []struct {
+		key     string
+		present bool
+	}{
+		{"versions", p.Versions != nil},
+		{"masks", p.Masks != nil},
+		{"metadata", p.Metadata != nil},
+		{"bugs", p.Bugs != nil},
+		{"pullRequests", p.PullRequests != nil},
+		{"qaReports", p.QAReports != nil},
+		{"reverseDependencies", p.ReverseDependencies != nil},
+		{"reverseDependencyVersions", p.ReverseDependencyVersions != nil},
+		{"changelog", p.Changelog != nil},
+		{"upstream", p.Upstream != nil},
+	}
+	sections := []string{}
+	for _, section := range present {
+		if section.present {
+			sections = append(sections, section.key)
+		}
+	}
+	return sections
+}
+
+func newVersions(gversions []*models.Version, withDependencies bool) []Version {
+	sorted := append([]*models.Version{}, gversions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GreaterThan(*sorted[j])
+	})
+
+	versions := []Version{}
+	for _, version := range sorted {
+		versions = append(versions, Version{
+			Version:    version.Version,
+			Slot:       version.Slot,
+			Subslot:    version.Subslot,
+			EAPI:       version.EAPI,
+			Keywords:   append([]string{}, strings.Fields(version.Keywords)...),
+			Restricts:  append([]string{}, version.Restricts...),
+			Properties: append([]string{}, version.Properties...),
+			Masked:     len(version.Masks) != 0,
+		})
+		if withDependencies {
+			dependencies := Dependencies(version.Dependencies)
+			versions[len(versions)-1].Dependencies = &dependencies
+		}
+	}
+	return versions
+}
+
+// dependencyTypes are the known dependency types in their display order
+var dependencyTypes = []string{"depend", "rdepend", "bdepend", "pdepend"}
+
+// Dependencies groups the given dependencies by their atom and USE
+// condition, and lists the dependency types used for each of them. The
+// dependencies are sorted by atom and condition.
+func Dependencies(deps []*models.ReverseDependency) []Dependency {
+	return groupDependencies(deps, func(dep *models.ReverseDependency) string {
+		return dep.Atom
+	})
+}
+
+// ReverseDependencies groups the given reverse dependencies by the
+// version that depends on the package and their USE condition, and
+// lists the dependency types used for each of them. The reverse
+// dependencies are sorted by version and condition.
+func ReverseDependencies(deps []*models.ReverseDependency) []Dependency {
+	return groupDependencies(deps, func(dep *models.ReverseDependency) string {
+		return dep.ReverseDependencyVersion
+	})
+}
+
+// groupDependencies groups the given dependencies by the atom returned
+// by atomOf and their USE condition
+func groupDependencies(deps []*models.ReverseDependency, atomOf func(*models.ReverseDependency) string) []Dependency {
+	type key struct{ atom, condition string }
+
+	dependencies := []Dependency{}
+	types := map[key]map[string]bool{}
+	for _, dep := range deps {
+		k := key{atomOf(dep), dep.Condition}
+		if types[k] == nil {
+			types[k] = map[string]bool{}
+			dependencies = append(dependencies, Dependency{Atom: k.atom, Condition: k.condition})
+		}
+		types[k][strings.ToLower(dep.Type)] = true
+	}
+
+	for idx, dependency := range dependencies {
+		dependencies[idx].Types = sortedTypes(types[key{dependency.Atom, dependency.Condition}])
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		if dependencies[i].Atom != dependencies[j].Atom {
+			return dependencies[i].Atom < dependencies[j].Atom
+		}
+		return dependencies[i].Condition < dependencies[j].Condition
+	})
+	return dependencies
+}
+
+// sortedTypes returns the given dependency types in their display order
+func sortedTypes(types map[string]bool) []string {
+	sorted := []string{}
+	for _, depType := range dependencyTypes {
+		if types[depType] {
+			sorted = append(sorted, depType)
+		}
+	}
+	var unknown []string
+	for depType := range types {
+		if !stringslice.Contains(dependencyTypes, depType) {
+			unknown = append(unknown, depType)
+		}
+	}
+	sort.Strings(unknown)
+	return append(sorted, unknown...)
+}
+
+// CollectMasks returns the masks of the given versions. Each mask lists the
+// versions it covers. The masks as well as the versions are sorted by the
+// newest version.
+func CollectMasks(gversions []*models.Version) []Mask {
+	sorted := append([]*models.Version{}, gversions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GreaterThan(*sorted[j])
+	})
+
+	masks := []Mask{}
+	maskIdx := map[string]int{}
+	for _, version := range sorted {
+		for _, mask := range version.Masks {
+			idx, ok := maskIdx[mask.Versions]
+			if !ok {
+				idx = len(masks)
+				maskIdx[mask.Versions] = idx
+				masks = append(masks, Mask{
+					Versions:    mask.Versions,
+					Author:      mask.Author,
+					AuthorEmail: mask.AuthorEmail,
+					Date:        mask.Date,
+					Reason:      mask.Reason,
+					Covers:      []string{},
+				})
+			}
+			masks[idx].Covers = append(masks[idx].Covers, version.Version)
+		}
+	}
+	return masks
+}
+
+func newMetadata(gpackage models.Package, useflags []models.Useflag) *Metadata {
+	metadata := &Metadata{
+		Longdescription: gpackage.Longdescription,
+		Useflags:        PackageUseflags(gpackage, useflags),
+		Maintainers:     []Maintainer{},
+	}
+	if len(gpackage.Versions) > 0 {
+		metadata.License = gpackage.Versions[0].License
+	}
+	for _, maintainer := range gpackage.Maintainers {
+		metadata.Maintainers = append(metadata.Maintainers, Maintainer{Name: maintainer.Name, Email: maintainer.Email})
+	}
+	return metadata
+}
+
+func newPullRequest(pr *models.GithubPullRequest) PullRequest {
+	pullRequest := PullRequest{
+		Id:          pr.Id,
+		Title:       pr.Title,
+		Author:      pr.Author,
+		Url:         pr.Url,
+		CiState:     pr.CiState,
+		CiStateLink: pr.CiStateLink,
+		Labels:      []string{},
+		Comments:    pr.Comments,
+		Files:       []PullRequestFile{},
+		CreatedAt:   pr.CreatedAt,
+		UpdatedAt:   pr.UpdatedAt,
+	}
+	for _, label := range pr.Labels {
+		pullRequest.Labels = append(pullRequest.Labels, label.Name)
+	}
+	for _, file := range pr.Files {
+		pullRequest.Files = append(pullRequest.Files, PullRequestFile{Path: file.Path, Additions: file.Additions, Deletions: file.Deletions})
+	}
+	return pullRequest
+}
+
+func newQAReports(gpackage models.Package) []QAReport {
+	qaReports := []QAReport{}
+	for _, qareport := range gpackage.PkgCheckResults {
+		qaReports = append(qaReports, QAReport{Class: qareport.Class, Message: qareport.Message})
+	}
+	for _, version := range gpackage.Versions {
+		for _, qareport := range version.PkgCheckResults {
+			qaReports = append(qaReports, QAReport{Version: version.Version, Class: qareport.Class, Message: qareport.Message})
+		}
+	}
+	return qaReports
+}
+
+func newChangelog(commits []*models.Commit) []Commit {
+	sorted := append([]*models.Commit{}, commits...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PrecedingCommits > sorted[j].PrecedingCommits
+	})
+
+	changelog := []Commit{}
+	for _, commit := range sorted {
+		changelog = append(changelog, Commit{
+			Id:      commit.Id,
+			Date:    commit.CommitterDate,
+			Author:  commit.CommitterName,
+			Message: commit.Message,
+		})
+	}
+	return changelog
+}
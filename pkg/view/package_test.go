@@ -0,0 +1,216 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+)
+
+func testPackage() models.Package {
+	return models.Package{
+		Atom:     "dev-lang/go",
+		Category: "dev-lang",
+		Name:     "go",
+		Versions: []*models.Version{
+			{Version: "1.13.12", Keywords: "amd64 ~arm64", Homepage: []string{"https://golang.org"}, Description: "Go"},
+			{Version: "1.14.4", Keywords: "~amd64 ~arm64", Slot: "0", Subslot: "1.14", EAPI: "7", Restricts: []string{"strip"}},
+		},
+		ReverseDependencies: []*models.ReverseDependency{
+			{ReverseDependencyAtom: "dev-go/b", ReverseDependencyVersion: "dev-go/b-2.0", Type: "rdepend", Condition: "go"},
+			{ReverseDependencyAtom: "dev-go/a", ReverseDependencyVersion: "dev-go/a-1.0", Type: "bdepend"},
+			{ReverseDependencyAtom: "dev-go/b", ReverseDependencyVersion: "dev-go/b-2.0", Type: "depend", Condition: "go"},
+		},
+	}
+}
+
+// encodeKeys encodes the view of the package as json and returns its top-level keys
+func encodeKeys(t *testing.T, p Package) map[string]json.RawMessage {
+	var buf bytes.Buffer
+	if err := Encode(&buf, "json", p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &keys); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	return keys
+}
+
+func TestNewPackage_Sections(t *testing.T) {
+	var tests = []struct {
+		sections client.Section
+		want     []string
+		notWant  []string
+	}{
+		{client.SectionVersions, []string{"atom", "homepage", "sections", "versions"}, []string{"bugs", "changelog", "metadata"}},
+		{client.SectionBugs, []string{"atom", "bugs"}, []string{"versions", "reverseDependencies"}},
+		{client.AllSections, []string{"versions", "metadata", "bugs", "pullRequests", "qaReports", "reverseDependencies", "reverseDependencyVersions", "changelog", "upstream"}, nil},
+	}
+
+	for _, tt := range tests {
+		keys := encodeKeys(t, NewPackage(testPackage(), tt.sections, nil))
+		for _, want := range tt.want {
+			if _, ok := keys[want]; !ok {
+				t.Errorf("sections %b: missing key %s", tt.sections, want)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if _, ok := keys[notWant]; ok {
+				t.Errorf("sections %b: unexpected key %s", tt.sections, notWant)
+			}
+		}
+	}
+}
+
+func TestNewPackage_EmptySection(t *testing.T) {
+	keys := encodeKeys(t, NewPackage(testPackage(), client.SectionBugs, nil))
+	if got := string(keys["bugs"]); got != "[]" {
+		t.Errorf("got %s, want []", got)
+	}
+}
+
+func TestNewPackage_SectionsInToml(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, "toml", NewPackage(testPackage(), client.SectionBugs|client.SectionUpstream, nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tree, err := toml.LoadBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("invalid toml: %v", err)
+	}
+	sections, ok := tree.Get("sections").([]interface{})
+	if !ok || len(sections) != 2 || sections[0] != "bugs" || sections[1] != "upstream" {
+		t.Errorf("got sections %v, want [bugs upstream]", tree.Get("sections"))
+	}
+}
+
+func TestNewPackage_Content(t *testing.T) {
+	p := NewPackage(testPackage(), client.AllSections, nil)
+
+	if p.Description != "Go" {
+		t.Errorf("got description %s, want Go", p.Description)
+	}
+	if got := (*p.Versions)[0].Version; got != "1.14.4" {
+		t.Errorf("got newest version %s, want 1.14.4", got)
+	}
+	if got := (*p.Versions)[0]; got.Slot != "0" || got.Subslot != "1.14" || got.EAPI != "7" || len(got.Restricts) != 1 {
+		t.Errorf("got %+v, want slot 0/1.14, EAPI 7 and restricts [strip]", got)
+	}
+	if got := (*p.Versions)[1].Keywords; len(got) != 2 || got[0] != "amd64" {
+		t.Errorf("got keywords %v, want [amd64 ~arm64]", got)
+	}
+	if got := *p.ReverseDependencies; len(got) != 2 || got[0] != "dev-go/a" || got[1] != "dev-go/b" {
+		t.Errorf("got reverse dependencies %v, want [dev-go/a dev-go/b]", got)
+	}
+}
+
+func TestEncode_Formats(t *testing.T) {
+	var decoders = map[string]func([]byte) (map[string]interface{}, error){
+		"json": func(data []byte) (map[string]interface{}, error) {
+			keys := map[string]interface{}{}
+			return keys, json.Unmarshal(data, &keys)
+		},
+		"yaml": func(data []byte) (map[string]interface{}, error) {
+			keys := map[string]interface{}{}
+			return keys, yaml.Unmarshal(data, &keys)
+		},
+		"toml": func(data []byte) (map[string]interface{}, error) {
+			tree, err := toml.LoadBytes(data)
+			if err != nil {
+				return nil, err
+			}
+			return tree.ToMap(), nil
+		},
+	}
+
+	for format, decode := range decoders {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, format, NewPackage(testPackage(), client.SectionVersions, nil)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			keys, err := decode(buf.Bytes())
+			if err != nil {
+				t.Fatalf("invalid %s: %v", format, err)
+			}
+			if keys["atom"] != "dev-lang/go" {
+				t.Errorf("got atom %v, want dev-lang/go", keys["atom"])
+			}
+			if _, ok := keys["versions"]; !ok {
+				t.Errorf("missing key versions")
+			}
+			if _, ok := keys["bugs"]; ok {
+				t.Errorf("unexpected key bugs")
+			}
+		})
+	}
+}
+
+func TestEncode_UnknownFormat(t *testing.T) {
+	if err := Encode(&bytes.Buffer{}, "xml", Package{}); err == nil {
+		t.Errorf("got no error, want an error")
+	}
+}
+
+func TestCollectMasks(t *testing.T) {
+	lastRite := &models.Mask{Versions: "dev-lang/go", Reason: "Last rites"}
+	security := &models.Mask{Versions: "<dev-lang/go-1.14", Reason: "Security"}
+	versions := []*models.Version{
+		{Version: "1.13", Masks: []*models.Mask{lastRite, security}},
+		{Version: "1.14", Masks: []*models.Mask{lastRite}},
+		{Version: "1.12", Masks: []*models.Mask{security, lastRite}},
+		{Version: "1.11"},
+	}
+
+	masks := CollectMasks(versions)
+	if len(masks) != 2 {
+		t.Fatalf("got %d masks, want 2", len(masks))
+	}
+	if masks[0].Versions != "dev-lang/go" || strings.Join(masks[0].Covers, " ") != "1.14 1.13 1.12" {
+		t.Errorf("got %s covering %v, want dev-lang/go covering [1.14 1.13 1.12]", masks[0].Versions, masks[0].Covers)
+	}
+	if masks[1].Versions != "<dev-lang/go-1.14" || strings.Join(masks[1].Covers, " ") != "1.13 1.12" {
+		t.Errorf("got %s covering %v, want <dev-lang/go-1.14 covering [1.13 1.12]", masks[1].Versions, masks[1].Covers)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	deps := []*models.ReverseDependency{
+		{Atom: "sys-devel/gcc", Type: "rdepend", Condition: "gccgo"},
+		{Atom: "dev-lang/go-bootstrap", Type: "bdepend", Condition: "!gccgo"},
+		{Atom: "sys-devel/gcc", Type: "DEPEND", Condition: "gccgo"},
+		{Atom: "sys-devel/gcc", Type: "bdepend"},
+	}
+
+	var got []string
+	for _, dep := range Dependencies(deps) {
+		got = append(got, dep.Atom+"["+dep.Condition+"]:"+strings.Join(dep.Types, ","))
+	}
+	want := "dev-lang/go-bootstrap[!gccgo]:bdepend sys-devel/gcc[]:bdepend sys-devel/gcc[gccgo]:depend,rdepend"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestReverseDependencies(t *testing.T) {
+	deps := []*models.ReverseDependency{
+		{ReverseDependencyAtom: "app-misc/b", ReverseDependencyVersion: "app-misc/b-2.0", Type: "rdepend", Condition: "go"},
+		{ReverseDependencyAtom: "dev-go/a", ReverseDependencyVersion: "dev-go/a-1.0", Type: "bdepend"},
+		{ReverseDependencyAtom: "app-misc/b", ReverseDependencyVersion: "app-misc/b-2.0", Type: "depend", Condition: "go"},
+	}
+
+	var got []string
+	for _, dep := range ReverseDependencies(deps) {
+		got = append(got, dep.Atom+"["+dep.Condition+"]:"+strings.Join(dep.Types, ","))
+	}
+	want := "app-misc/b-2.0[go]:depend,rdepend dev-go/a-1.0[]:bdepend"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got %s, want %s", strings.Join(got, " "), want)
+	}
+}
@@ -0,0 +1,259 @@
+// Contains the markdown and html reports of a package
+
+package view
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"reflect"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// DefaultArches are the architectures shown in the versions table
+var DefaultArches = []string{"amd64", "x86", "alpha", "arm", "arm64", "hppa", "ia64", "ppc", "ppc64", "sparc"}
+
+// KeywordSymbol returns the symbol describing the state of the version on the
+// given arch, that is 'x' if masked, '+' if stable, '~' if testing, '-' if
+// disabled and an empty string otherwise.
+func (v Version) KeywordSymbol(arch string) string {
+	if v.Masked {
+		return "x"
+	}
+	switch models.ParseKeywords(strings.Join(v.Keywords, " ")).State(arch) {
+	case models.KeywordStable:
+		return "+"
+	case models.KeywordTesting:
+		return "~"
+	case models.KeywordDisabled:
+		return "-"
+	}
+	return ""
+}
+
+// Report is a Package that is rendered as markdown or html, showing
+// the given arches in the versions table
+type Report struct {
+	Package
+	Arches []string
+}
+
+// AllArches returns the sorted arches that are listed in the given
+// keywords, i.e. amd64 and arm64 for '~amd64 -arm64 -*'
+func AllArches(keywords []string) []string {
+	return models.ParseKeywords(strings.Join(keywords, " ")).Arches()
+}
+
+var reportFuncs = map[string]interface{}{
+	"join":     strings.Join,
+	"shortId":  shortId,
+	"cell":     markdownCell,
+	"nonEmpty": nonEmpty,
+	"oneline":  oneline,
+	"date":     date,
+}
+
+var markdownTemplate = texttemplate.Must(texttemplate.New("markdown").Funcs(reportFuncs).Parse(`# {{.Atom}}
+
+{{.Description}}
+{{range .Homepage}}
+<{{.}}>
+{{end}}
+{{- with .Versions}}
+## Available Versions
+
+| Version |{{range $.Arches}} {{.}} |{{end}}
+|---|{{range $.Arches}}:-:|{{end}}
+{{range .}}| {{cell .Version}} |{{$version := .}}{{range $.Arches}} {{with $version.KeywordSymbol .}}` + "`{{.}}`" + `{{end}} |{{end}}
+{{end}}{{end}}
+{{- if nonEmpty .Masks}}{{with .Masks}}
+## Masks
+{{range .}}
+- **{{.Versions}}** (covers {{join .Covers ", "}}): masked by {{.Author}} <{{.AuthorEmail}}> on {{.Date.Format "2006-01-02"}}  
+  {{oneline .Reason}}{{end}}
+{{end}}{{end}}
+{{- with .Metadata}}
+## Package Metadata
+{{if .Longdescription}}
+- **Full description:** {{.Longdescription}}{{end}}
+- **Useflags:**{{range .Useflags}}
+  - ` + "`{{.Name}}`" + `{{with .Description}}: {{.}}{{end}}{{end}}
+- **License:** {{.License}}
+- **Maintainers:** {{range $idx, $maintainer := .Maintainers}}{{if $idx}}, {{end}}{{with $maintainer.Name}}{{.}} {{end}}<{{$maintainer.Email}}>{{end}}
+{{end}}
+{{- if nonEmpty .Bugs}}{{with .Bugs}}
+## Bugs
+{{range .}}
+- {{if .Security}}**Security** {{end}}[{{.Id}}](https://bugs.gentoo.org/{{.Id}}): {{.Summary}}{{if .Status}} ({{.Status}}{{with .Component}}, {{.}}{{end}}{{with .Assignee}}, {{.}}{{end}}){{end}}{{end}}
+{{end}}{{end}}
+{{- if nonEmpty .PullRequests}}{{with .PullRequests}}
+## Pull Requests
+{{range .}}
+- [#{{.Id}}](https://github.com/gentoo/gentoo/pull/{{.Id}}): {{.Title}} ({{.Author}}){{with date .CreatedAt}}, opened {{.}}{{end}}{{with date .UpdatedAt}}, updated {{.}}{{end}}{{if .CiState}}, CI: {{if .CiStateLink}}[{{.CiState}}]({{.CiStateLink}}){{else}}{{.CiState}}{{end}}{{end}}{{with .Labels}}, labels: {{join . ", "}}{{end}}{{end}}
+{{end}}{{end}}
+{{- if nonEmpty .QAReports}}{{with .QAReports}}
+## QA Report
+{{range .}}
+- **{{with .Version}}{{.}}{{else}}All Versions{{end}}:** {{.Class}}: {{.Message}}{{end}}
+{{end}}{{end}}
+{{- with .Versions}}{{range .}}{{if nonEmpty .Dependencies}}{{$version := .Version}}{{with .Dependencies}}
+## Dependencies of {{$.Atom}}-{{$version}}
+{{range .}}
+- {{.Atom}} ({{join .Types ", "}}){{with .Condition}} if ` + "`{{.}}`" + `{{end}}{{end}}
+{{end}}{{end}}{{end}}{{end}}
+{{- if nonEmpty .ReverseDependencyVersions}}{{with .ReverseDependencyVersions}}
+## Reverse Dependencies
+{{range .}}
+- {{.Atom}} ({{join .Types ", "}}){{with .Condition}} if ` + "`{{.}}`" + `{{end}}{{end}}
+{{end}}{{end}}
+{{- with .Changelog}}
+## Changelog
+{{range .}}
+- {{.Date.Format "2006-01-02"}}, ` + "`{{shortId .Id}}`" + `: {{.Message}} ({{.Author}}){{end}}
+{{end}}
+{{- if nonEmpty .Upstream}}{{with .Upstream}}
+## Upstream
+{{range .}}
+- {{.GentooVersion}} in Gentoo, {{.NewestVersion}} released upstream{{end}}
+{{end}}{{end}}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(reportFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Atom}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; text-align: center; }
+td.version { text-align: left; font-weight: bold; }
+.stable { background: #dff0d8; }
+.testing { background: #fcf8e3; }
+.masked { background: #f2dede; }
+.disabled { color: #a94442; }
+.security { color: #a94442; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Atom}}</h1>
+<p>{{.Description}}</p>
+{{range .Homepage}}<p><a href="{{.}}">{{.}}</a></p>
+{{end}}
+{{- with .Versions}}
+<h2>Available Versions</h2>
+<table>
+<tr><th>Version</th>{{range $.Arches}}<th>{{.}}</th>{{end}}</tr>
+{{range .}}<tr><td class="version">{{.Version}}</td>{{$version := .}}{{range $.Arches}}{{$symbol := $version.KeywordSymbol .}}<td{{if eq $symbol "+"}} class="stable"{{else if eq $symbol "~"}} class="testing"{{else if eq $symbol "x"}} class="masked"{{else if eq $symbol "-"}} class="disabled"{{end}}>{{$symbol}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+{{- if nonEmpty .Masks}}{{with .Masks}}
+<h2>Masks</h2>
+<ul>
+{{range .}}<li><b>{{.Versions}}</b> (covers {{join .Covers ", "}}): masked by <a href="mailto:{{.AuthorEmail}}">{{.Author}}</a> on {{.Date.Format "2006-01-02"}}<br>{{.Reason}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+{{- with .Metadata}}
+<h2>Package Metadata</h2>
+<ul>
+{{if .Longdescription}}<li><b>Full description:</b> {{.Longdescription}}</li>
+{{end}}<li><b>Useflags:</b><ul>{{range .Useflags}}<li><code>{{.Name}}</code>{{with .Description}}: {{.}}{{end}}</li>{{end}}</ul></li>
+<li><b>License:</b> {{.License}}</li>
+<li><b>Maintainers:</b> {{range $idx, $maintainer := .Maintainers}}{{if $idx}}, {{end}}<a href="mailto:{{$maintainer.Email}}">{{with $maintainer.Name}}{{.}}{{else}}{{$maintainer.Email}}{{end}}</a>{{end}}</li>
+</ul>
+{{end}}
+{{- if nonEmpty .Bugs}}{{with .Bugs}}
+<h2>Bugs</h2>
+<ul>
+{{range .}}<li{{if .Security}} class="security"{{end}}><a href="https://bugs.gentoo.org/{{.Id}}">{{.Id}}</a>: {{.Summary}}{{if .Status}} ({{.Status}}{{with .Component}}, {{.}}{{end}}{{with .Assignee}}, {{.}}{{end}}){{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+{{- if nonEmpty .PullRequests}}{{with .PullRequests}}
+<h2>Pull Requests</h2>
+<ul>
+{{range .}}<li><a href="https://github.com/gentoo/gentoo/pull/{{.Id}}">#{{.Id}}</a>: {{.Title}} ({{.Author}}){{with date .CreatedAt}}, opened {{.}}{{end}}{{with date .UpdatedAt}}, updated {{.}}{{end}}{{if .CiState}}, CI: {{if .CiStateLink}}<a href="{{.CiStateLink}}">{{.CiState}}</a>{{else}}{{.CiState}}{{end}}{{end}}{{with .Labels}}, labels: {{join . ", "}}{{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+{{- if nonEmpty .QAReports}}{{with .QAReports}}
+<h2>QA Report</h2>
+<ul>
+{{range .}}<li><b>{{with .Version}}{{.}}{{else}}All Versions{{end}}:</b> {{.Class}}: {{.Message}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+{{- with .Versions}}{{range .}}{{if nonEmpty .Dependencies}}{{$version := .Version}}{{with .Dependencies}}
+<h2>Dependencies of {{$.Atom}}-{{$version}}</h2>
+<ul>
+{{range .}}<li>{{.Atom}} ({{join .Types ", "}}){{with .Condition}} if <code>{{.}}</code>{{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}{{end}}{{end}}
+{{- if nonEmpty .ReverseDependencyVersions}}{{with .ReverseDependencyVersions}}
+<h2>Reverse Dependencies</h2>
+<ul>
+{{range .}}<li>{{.Atom}} ({{join .Types ", "}}){{with .Condition}} if <code>{{.}}</code>{{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}
+{{- with .Changelog}}
+<h2>Changelog</h2>
+<ul>
+{{range .}}<li>{{.Date.Format "2006-01-02"}}, <code>{{shortId .Id}}</code>: {{.Message}} ({{.Author}})</li>
+{{end}}</ul>
+{{end}}
+{{- if nonEmpty .Upstream}}{{with .Upstream}}
+<h2>Upstream</h2>
+<ul>
+{{range .}}<li>{{.GentooVersion}} in Gentoo, {{.NewestVersion}} released upstream</li>
+{{end}}</ul>
+{{end}}{{end}}
+</body>
+</html>
+`))
+
+// writeReport writes the report in the given format
+func writeReport(w io.Writer, format string, data Report) error {
+	if format == "html" {
+		return htmlTemplate.Execute(w, data)
+	}
+	return markdownTemplate.Execute(w, data)
+}
+
+// nonEmpty returns true if the given section has been requested and is not empty
+func nonEmpty(section interface{}) bool {
+	v := reflect.ValueOf(section)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return v.Len() > 0
+}
+
+// oneline joins the lines of the given text
+func oneline(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// date returns the day of the given RFC 3339 timestamp, or an
+// empty string if it can't be parsed
+func date(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// shortId returns the abbreviated commit id
+func shortId(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+// markdownCell escapes the given string to be used in a markdown table cell
+func markdownCell(str string) string {
+	return strings.ReplaceAll(str, "|", `\|`)
+}
@@ -0,0 +1,98 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestVersion_KeywordSymbol(t *testing.T) {
+	var tests = []struct {
+		version Version
+		arch    string
+		want    string
+	}{
+		{Version{Keywords: []string{"amd64", "~arm64"}}, "amd64", "+"},
+		{Version{Keywords: []string{"amd64", "~arm64"}}, "arm64", "~"},
+		{Version{Keywords: []string{"amd64", "~arm64"}}, "x86", ""},
+		{Version{Keywords: []string{"amd64"}, Masked: true}, "amd64", "x"},
+		{Version{Keywords: []string{"amd64", "-sparc"}}, "sparc", "-"},
+		{Version{Keywords: []string{"amd64", "-*"}}, "x86", "-"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.version.KeywordSymbol(tt.arch); got != tt.want {
+			t.Errorf("%v on %s: got %q, want %q", tt.version, tt.arch, got, tt.want)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	gpackage := testPackage()
+	gpackage.Bugs = []*models.Bug{{Id: "12345", Summary: "<script>", Product: "Gentoo Security", Component: "Vulnerabilities", Status: "CONFIRMED"}}
+	gpackage.PullRequests = []*models.GithubPullRequest{{Id: "111", Title: "bump", CiState: "SUCCESS", CiStateLink: "https://ci", CreatedAt: "2020-05-01T10:00:00Z", UpdatedAt: "2020-06-01T10:00:00Z", Labels: []models.GitHubPullRequestLabelNode{{Name: "assigned"}}}}
+	gpackage.Outdated = []*models.OutdatedPackages{{Atom: "dev-lang/go", GentooVersion: "1.14.4", NewestVersion: "1.15"}}
+	gpackage.Versions[0].Masks = []*models.Mask{{Versions: "=dev-lang/go-1.13.12", Author: "Bob", Reason: "Security\nRemoval in 30 days"}}
+	gpackage.Versions[1].Useflags = []string{"gccgo"}
+	useflags := []models.Useflag{{Name: "gccgo", Description: "Use gccgo", Package: "dev-lang/go"}}
+	gpackage.Versions[1].Dependencies = []*models.ReverseDependency{{Atom: "sys-devel/gcc", Type: "bdepend", Condition: "gccgo"}}
+
+	var tests = []struct {
+		format  string
+		want    []string
+		notWant []string
+	}{
+		{"markdown",
+			[]string{"# dev-lang/go", "## Available Versions", "| 1.13.12 | `x` |", "| 1.14.4 | `~` |", "**Security** [12345](https://bugs.gentoo.org/12345): <script> (CONFIRMED, Vulnerabilities)", "## Reverse Dependencies", "- dev-go/b-2.0 (depend, rdepend) if `go`",
+				"## Dependencies of dev-lang/go-1.14.4", "  - `gccgo`: Use gccgo", "- sys-devel/gcc (bdepend) if `gccgo`",
+				"**=dev-lang/go-1.13.12** (covers 1.13.12): masked by Bob", "Security Removal in 30 days",
+				"opened 2020-05-01, updated 2020-06-01, CI: [SUCCESS](https://ci), labels: assigned", "## Upstream", "- 1.14.4 in Gentoo, 1.15 released upstream"},
+			[]string{"## QA Report"}},
+		{"html",
+			[]string{"<h1>dev-lang/go</h1>", `<li class="security">`, `<td class="masked">`, "&lt;script&gt;", "<li>dev-go/a-1.0 (bdepend)</li>",
+				"<h2>Dependencies of dev-lang/go-1.14.4</h2>", "<li><code>gccgo</code>: Use gccgo</li>", "<li>sys-devel/gcc (bdepend) if <code>gccgo</code></li>", "<b>=dev-lang/go-1.13.12</b>",
+				`opened 2020-05-01, updated 2020-06-01, CI: <a href="https://ci">SUCCESS</a>, labels: assigned`, "<li>1.14.4 in Gentoo, 1.15 released upstream</li>"},
+			[]string{"<script>", "<h2>QA Report</h2>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, tt.format, NewPackage(gpackage, client.AllSections, useflags)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("report does not contain %s:\n%s", want, buf.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("report contains %s:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestAllArches(t *testing.T) {
+	got := AllArches([]string{"~amd64 -sparc -*", "arm64 ~riscv", "amd64 **"})
+	want := []string{"amd64", "arm64", "riscv", "sparc"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteReport_Arches(t *testing.T) {
+	var buf bytes.Buffer
+	report := Report{Package: NewPackage(testPackage(), client.SectionVersions, nil), Arches: []string{"arm64", "riscv"}}
+	if err := Encode(&buf, "markdown", report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "| Version | arm64 | riscv |") {
+		t.Errorf("report does not contain the arches:\n%s", buf.String())
+	}
+}
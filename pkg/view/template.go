@@ -0,0 +1,86 @@
+// Contains the helpers of user-defined output templates
+
+package view
+
+import (
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+// templateFuncs are the functions available in user-defined templates
+var templateFuncs = template.FuncMap{
+	"latest":       latest,
+	"latestStable": latestStable,
+	"maintainers":  maintainers,
+	"bugCount":     bugCount,
+	"join":         strings.Join,
+	"json":         toJSON,
+}
+
+// NewTemplate parses a user-defined template, i.e.
+//   {{.Atom}} {{range .Versions}}{{.Version}} {{end}}
+// that is executed against a models.Package. Besides the
+// builtin functions, the following functions are available:
+//   latest .Versions              newest version
+//   latestStable .Versions "arch" newest version that is stable on arch
+//   maintainers .Maintainers      comma-separated maintainer names
+//   bugCount .Bugs                number of bugs
+//   join .Slice "sep"             strings.Join
+//   json .                        value encoded as json
+func NewTemplate(text string) (*template.Template, error) {
+	return template.New("format").Funcs(templateFuncs).Parse(text)
+}
+
+// latest returns the newest of the given versions
+func latest(versions []*models.Version) string {
+	var newest *models.Version
+	for _, version := range versions {
+		if newest == nil || version.GreaterThan(*newest) {
+			newest = version
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.Version
+}
+
+// latestStable returns the newest of the given versions that
+// is stable and not masked on the given arch
+func latestStable(versions []*models.Version, arch string) string {
+	var stable []*models.Version
+	for _, version := range versions {
+		if len(version.Masks) == 0 && version.ParseKeywords().StableOn(arch) {
+			stable = append(stable, version)
+		}
+	}
+	return latest(stable)
+}
+
+// maintainers returns the comma-separated names of the maintainers,
+// falling back to the email in case the name is missing
+func maintainers(maintainers []*models.Maintainer) string {
+	var names []string
+	for _, maintainer := range maintainers {
+		if maintainer.Name != "" {
+			names = append(names, maintainer.Name)
+		} else {
+			names = append(names, maintainer.Email)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// bugCount returns the number of bugs
+func bugCount(bugs []*models.Bug) int {
+	return len(bugs)
+}
+
+// toJSON returns the value encoded as json
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
@@ -0,0 +1,53 @@
+package view
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestNewTemplate(t *testing.T) {
+	gpackage := testPackage()
+	gpackage.Versions = append(gpackage.Versions,
+		&models.Version{Version: "1.13.11", Keywords: "amd64 arm64"},
+		&models.Version{Version: "1.15_beta1", Keywords: "amd64", Masks: []*models.Mask{{Versions: "=dev-lang/go-1.15_beta1"}}})
+	gpackage.Maintainers = []*models.Maintainer{{Name: "Go Project", Email: "go@gentoo.org"}, {Email: "dev@gentoo.org"}}
+	gpackage.Bugs = []*models.Bug{{Id: "1"}, {Id: "2"}}
+
+	var tests = []struct {
+		format string
+		want   string
+	}{
+		{`{{.Atom}} {{range .Versions}}{{.Version}} {{end}}`, "dev-lang/go 1.13.12 1.14.4 1.13.11 1.15_beta1 "},
+		{`{{latest .Versions}}`, "1.15_beta1"},
+		{`{{latestStable .Versions "amd64"}}`, "1.13.12"},
+		{`{{latestStable .Versions "arm64"}}`, "1.13.11"},
+		{`{{latestStable .Versions "riscv"}}`, ""},
+		{`{{maintainers .Maintainers}}`, "Go Project, dev@gentoo.org"},
+		{`{{bugCount .Bugs}}`, "2"},
+		{`{{json .Name}}`, `"go"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			tmpl, err := NewTemplate(tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, gpackage); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplate_Invalid(t *testing.T) {
+	if _, err := NewTemplate(`{{.Atom`); err == nil {
+		t.Errorf("got no error, want an error")
+	}
+}
@@ -0,0 +1,73 @@
+package view
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/arzano/pgo/pkg/models"
+	"github.com/arzano/pgo/pkg/stringslice"
+)
+
+// Useflag is a USE flag of a package with its description
+type Useflag struct {
+	Name        string `json:"name" yaml:"name" toml:"name"`
+	Description string `json:"description" yaml:"description" toml:"description"`
+	// UseExpand is the USE_EXPAND group, i.e. 'python_targets'
+	UseExpand string `json:"useExpand,omitempty" yaml:"useExpand,omitempty" toml:"useExpand,omitempty"`
+}
+
+// PackageUseflags returns the USE flags of the newest version of the
+// package that is not a live version, described using the given USE
+// flag records, which have been looked up separately.
+func PackageUseflags(gpackage models.Package, records []models.Useflag) []Useflag {
+	return Useflags(gpackage.Atom, UseflagNames(gpackage), records)
+}
+
+// UseflagNames returns the names of the USE flags of the newest version
+// of the package that is not a live version, without their default, i.e.
+// 'gccgo' for '+gccgo'
+func UseflagNames(gpackage models.Package) []string {
+	names := []string{}
+	if version := models.NewestRelease(gpackage.Versions); version != nil {
+		for _, useflag := range version.Useflags {
+			names = append(names, strings.TrimLeft(useflag, "+-"))
+		}
+	}
+	return names
+}
+
+// Useflags describes the given USE flags of the package with the given
+// atom using the given USE flag records. The local description of the
+// package takes precedence over the USE_EXPAND and global ones. The flags
+// are sorted by their USE_EXPAND group and name, starting with the flags
+// that are not part of any group.
+func Useflags(atom string, names []string, records []models.Useflag) []Useflag {
+	useflags := []Useflag{}
+	for _, name := range stringslice.Unique(names) {
+		useflag := Useflag{Name: name}
+		local := false
+		for _, record := range records {
+			if record.Name != name || (record.Package != "" && record.Package != atom) {
+				continue
+			}
+			if record.UseExpand != "" {
+				useflag.UseExpand = record.UseExpand
+			}
+			if record.Package == atom {
+				useflag.Description = record.Description
+				local = true
+			} else if !local && (useflag.Description == "" || record.UseExpand != "") {
+				useflag.Description = record.Description
+			}
+		}
+		useflags = append(useflags, useflag)
+	}
+
+	sort.SliceStable(useflags, func(i, j int) bool {
+		if useflags[i].UseExpand != useflags[j].UseExpand {
+			return useflags[i].UseExpand < useflags[j].UseExpand
+		}
+		return useflags[i].Name < useflags[j].Name
+	})
+	return useflags
+}
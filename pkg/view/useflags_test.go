@@ -0,0 +1,74 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/arzano/pgo/pkg/client"
+	"github.com/arzano/pgo/pkg/models"
+)
+
+func TestUseflags(t *testing.T) {
+	records := []models.Useflag{
+		{Name: "doc", Scope: "global", Description: "Add extra documentation"},
+		{Name: "doc", Scope: "local", Description: "Build the API docs", Package: "dev-lang/go"},
+		{Name: "gccgo", Scope: "local", Description: "Use gccgo", Package: "dev-lang/go"},
+		{Name: "gccgo", Scope: "local", Description: "Something else", Package: "dev-go/a"},
+		{Name: "python_targets_python3_8", Scope: "use_expand", Description: "Build with Python 3.8", UseExpand: "python_targets"},
+		{Name: "test", Scope: "global", Description: "Enable tests"},
+	}
+	names := []string{"test", "python_targets_python3_8", "gccgo", "doc", "unknown", "doc"}
+
+	want := []Useflag{
+		{Name: "doc", Description: "Build the API docs"},
+		{Name: "gccgo", Description: "Use gccgo"},
+		{Name: "test", Description: "Enable tests"},
+		{Name: "unknown"},
+		{Name: "python_targets_python3_8", Description: "Build with Python 3.8", UseExpand: "python_targets"},
+	}
+
+	got := Useflags("dev-lang/go", names, records)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got[i], want[i])
+		}
+	}
+	if names[0] != "test" {
+		t.Errorf("the given names have been modified")
+	}
+}
+
+func TestPackageUseflags(t *testing.T) {
+	gpackage := models.Package{
+		Atom: "dev-lang/go",
+		Versions: []*models.Version{
+			{Version: "9999", Useflags: []string{"live-only"}},
+			{Version: "1.14.4", Useflags: []string{"+gccgo", "-doc"}},
+			{Version: "1.13.12", Useflags: []string{"old"}},
+		},
+	}
+	records := []models.Useflag{
+		{Name: "gccgo", Description: "Use gccgo", Package: "dev-lang/go"},
+		{Name: "gccgo", Description: "Something else", Package: "dev-go/a"},
+	}
+
+	want := []Useflag{
+		{Name: "doc"},
+		{Name: "gccgo", Description: "Use gccgo"},
+	}
+
+	got := PackageUseflags(gpackage, records)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got[i], want[i])
+		}
+	}
+	if metadata := NewPackage(gpackage, client.SectionMetadata, records).Metadata; len(metadata.Useflags) != len(want) || metadata.Useflags[1] != want[1] {
+		t.Errorf("got metadata useflags %v, want %v", metadata.Useflags, want)
+	}
+}